@@ -0,0 +1,58 @@
+package main
+
+import (
+	"time"
+
+	"github.com/atuleu/go-tablifier"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+)
+
+type BroadcastClientsCommand struct {
+	Args struct {
+		Node Nodename
+	} `positional-args:"yes" required:"yes"`
+}
+
+var broadcastClientsCommand = &BroadcastClientsCommand{}
+
+type BroadcastClientTableLine struct {
+	Address string
+	Uptime  string
+	Dropped int64
+}
+
+func (c *BroadcastClientsCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	list, err := n.ListBroadcastClients()
+	if err != nil {
+		return err
+	}
+	c.printList(list.Clients, time.Now())
+	return nil
+}
+
+func (c *BroadcastClientsCommand) printList(clients []*letopb.BroadcastClient, now time.Time) {
+	lines := make([]BroadcastClientTableLine, 0, len(clients))
+	for _, client := range clients {
+		lines = append(lines, BroadcastClientTableLine{
+			Address: client.Address,
+			Uptime:  now.Sub(client.Since.AsTime()).Round(time.Second).String(),
+			Dropped: client.Dropped,
+		})
+	}
+	tablifier.Tablify(lines)
+}
+
+func init() {
+	_, err := parser.AddCommand("broadcast-clients",
+		"lists clients of the tracking data broadcast",
+		"Lists the clients connected to the tracking data broadcast of a node, with the number of frames dropped because they were too slow",
+		broadcastClientsCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
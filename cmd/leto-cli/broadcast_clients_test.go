@@ -0,0 +1,32 @@
+package main
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ExampleBroadcastClientsCommand() {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	clients := []*letopb.BroadcastClient{
+		{
+			Address: "192.168.1.10:53412",
+			Since:   timestamppb.New(now.Add(-2 * time.Hour)),
+		},
+		{
+			Address: "10.0.0.4:40122",
+			Since:   timestamppb.New(now.Add(-90 * time.Second)),
+			Dropped: 1234,
+		},
+	}
+
+	(&BroadcastClientsCommand{}).printList(clients, now)
+	//output:
+	//┌────────────────────┬────────┬─────────┐
+	//│            Address │ Uptime │ Dropped │
+	//├────────────────────┼────────┼─────────┤
+	//│ 192.168.1.10:53412 │ 2h0m0s │ 0       │
+	//│     10.0.0.4:40122 │ 1m30s  │ 1234    │
+	//└────────────────────┴────────┴─────────┘
+}
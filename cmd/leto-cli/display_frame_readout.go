@@ -9,9 +9,14 @@ import (
 
 	"github.com/formicidae-tracker/hermes"
 	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/golang/protobuf/proto"
 )
 
 type DisplayFrameReadoutCommand struct {
+	Tags       []uint32 `long:"tag" description:"only counts these tags (can be set multiple times)"`
+	Decimation uint32   `long:"decimation" description:"only displays one frame every decimation"`
+
 	Args struct {
 		Node Nodename
 	} `positional-args:"yes" required:"yes"`
@@ -69,6 +74,22 @@ func (c *DisplayFrameReadoutCommand) Execute(args []string) error {
 		return fmt.Errorf("Did not receive an expected version header")
 	}
 
+	// only counts are displayed, there is no need to receive tag
+	// positions.
+	buf := proto.NewBuffer(nil)
+	if err := buf.EncodeMessage(&letopb.BroadcastSubscription{
+		TagIds:     c.Tags,
+		Decimation: c.Decimation,
+		CountsOnly: true,
+	}); err != nil {
+		conn.Close()
+		return err
+	}
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		conn.Close()
+		return fmt.Errorf("Could not subscribe to '%s': %s", n.Name, err)
+	}
+
 	go func() {
 		sigint := make(chan os.Signal, 1)
 		signal.Notify(sigint, os.Interrupt)
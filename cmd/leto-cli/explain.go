@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atuleu/go-tablifier"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"gopkg.in/yaml.v2"
+)
+
+type ProvenanceTableLine struct {
+	Field  string
+	Value  string
+	Source string
+}
+
+// printProvenance prints every field of a YAML configuration with
+// the layer which supplied it.
+func printProvenance(yamlConfiguration string, provenance map[string]string) {
+	if len(provenance) == 0 {
+		fmt.Printf("No configuration provenance recorded\n")
+		return
+	}
+
+	values := yaml.MapSlice{}
+	yaml.Unmarshal([]byte(yamlConfiguration), &values)
+
+	lines := make([]ProvenanceTableLine, 0, len(provenance))
+	for _, path := range provenancePaths(provenance) {
+		lines = append(lines, ProvenanceTableLine{
+			Field:  path,
+			Value:  formatYAMLValue(lookupYAMLPath(values, path)),
+			Source: provenance[path],
+		})
+	}
+	tablifier.Tablify(lines)
+}
+
+// provenancePaths returns the paths of provenance in configuration
+// order. Paths unknown to this version come last.
+func provenancePaths(provenance map[string]string) []string {
+	res := make([]string, 0, len(provenance))
+	known := make(map[string]bool)
+	for _, path := range leto.ConfigurationPaths() {
+		known[path] = true
+		if _, ok := provenance[path]; ok == true {
+			res = append(res, path)
+		}
+	}
+	unknown := []string{}
+	for path := range provenance {
+		if known[path] == false {
+			unknown = append(unknown, path)
+		}
+	}
+	sort.Strings(unknown)
+	return append(res, unknown...)
+}
+
+func lookupYAMLPath(values yaml.MapSlice, path string) interface{} {
+	var res interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		m, ok := res.(yaml.MapSlice)
+		if ok == false {
+			return nil
+		}
+		res = nil
+		for _, item := range m {
+			if item.Key == key {
+				res = item.Value
+				break
+			}
+		}
+	}
+	return res
+}
+
+func formatYAMLValue(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return "~"
+	case []interface{}:
+		items := make([]string, 0, len(vv))
+		for _, item := range vv {
+			items = append(items, formatYAMLValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case yaml.MapSlice:
+		items := make([]string, 0, len(vv))
+		for _, item := range vv {
+			items = append(items, fmt.Sprintf("%v: %s", item.Key, formatYAMLValue(item.Value)))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/atuleu/go-humanize"
+	"github.com/atuleu/go-tablifier"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"gopkg.in/yaml.v2"
+)
+
+type HistoryCommand struct {
+	Args struct {
+		Node Nodename `required:"yes"`
+		ID   *int32
+	} `positional-args:"yes"`
+	All           bool `short:"a" long:"all" description:"print all information of the selected experiment"`
+	Log           bool `short:"l" long:"log" description:"print artemis logs of the selected experiment"`
+	Stderr        bool `short:"e" long:"stderr" description:"print artemis stderr of the selected experiment"`
+	Configuration bool `short:"c" long:"configuration" description:"print the configuration of the selected experiment"`
+	Explain       bool `long:"explain" description:"print which configuration layer supplied each field of the selected experiment"`
+}
+
+var historyCommand = &HistoryCommand{}
+
+type HistoryTableLine struct {
+	ID         int32
+	Status     string `name:" "`
+	Experiment string
+	OutputDir  string `name:"Output Dir"`
+	Start      string
+	Duration   string
+}
+
+func (c *HistoryCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	if c.Args.ID != nil {
+		return c.printExperiment(n, *c.Args.ID)
+	}
+
+	list, err := n.ListExperiments()
+	if err != nil {
+		return err
+	}
+	c.printList(list.Experiments)
+	return nil
+}
+
+func (c *HistoryCommand) printExperiment(n *leto.Node, id int32) error {
+	log, err := n.GetExperimentLog(id)
+	if err != nil {
+		return err
+	}
+
+	config := leto.TrackingConfiguration{}
+	err = yaml.Unmarshal([]byte(log.YamlConfiguration), &config)
+	if err != nil {
+		return fmt.Errorf("Could not parse YAML configuration: %s", err)
+	}
+
+	(&LastExperimentLogCommand{
+		All:           c.All,
+		Log:           c.Log,
+		Stderr:        c.Stderr,
+		Configuration: c.Configuration,
+		Explain:       c.Explain,
+	}).printLog(log, config)
+	return nil
+}
+
+func (c *HistoryCommand) printList(experiments []*letopb.ExperimentLog) {
+	lines := make([]HistoryTableLine, 0, len(experiments))
+
+	timeFmt := "Mon _2 Jan 15:04 2006"
+
+	for _, log := range experiments {
+		config := leto.TrackingConfiguration{}
+		yaml.Unmarshal([]byte(log.YamlConfiguration), &config)
+
+		status := "\033[1;92m✓\033[m"
+		if log.HasError == true {
+			status = "\033[1;31m⚠\033[m"
+		}
+
+		start := log.Start.AsTime()
+		ellapsed := log.End.AsTime().Sub(start)
+
+		lines = append(lines, HistoryTableLine{
+			ID:         log.Id,
+			Status:     status,
+			Experiment: config.ExperimentName,
+			OutputDir:  log.ExperimentDir,
+			Start:      start.Local().Format(timeFmt),
+			Duration:   humanize.Duration(ellapsed).String(),
+		})
+	}
+
+	tablifier.Tablify(lines)
+}
+
+func init() {
+	_, err := parser.AddCommand("history",
+		"lists the experiments run on the node",
+		"Lists all experiments run on the node, or prints the log of a single experiment if its ID is given",
+		historyCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ExampleHistoryCommand() {
+	experiments := []*letopb.ExperimentLog{
+		{
+			Id:                0,
+			ExperimentDir:     "someexp.0000",
+			YamlConfiguration: "experiment: someexp",
+			Start:             timestamppb.New(time.Date(2023, 4, 1, 10, 58, 21, 0, time.UTC)),
+			End:               timestamppb.New(time.Date(2023, 4, 24, 18, 12, 01, 0, time.UTC)),
+		},
+		{
+			Id:                1,
+			ExperimentDir:     "TEST-MODE.0000",
+			YamlConfiguration: "experiment: TEST-MODE",
+			Start:             timestamppb.New(time.Date(2023, 4, 25, 9, 0, 0, 0, time.UTC)),
+			End:               timestamppb.New(time.Date(2023, 4, 25, 9, 5, 0, 0, time.UTC)),
+			HasError:          true,
+			Error:             "artemis crashed",
+		},
+	}
+
+	(&HistoryCommand{}).printList(experiments)
+	//output:
+	//┌────┬───┬────────────┬────────────────┬───────────────────────┬────────────────┐
+	//│ ID │   │ Experiment │ Output Dir     │ Start                 │ Duration       │
+	//├────┼───┼────────────┼────────────────┼───────────────────────┼────────────────┤
+	//│  0 │ [1;92m✓[m │ someexp    │ someexp.0000   │ Sat  1 Apr 12:58 2023 │ 3 weeks 2 days │
+	//│  1 │ [1;31m⚠[m │ TEST-MODE  │ TEST-MODE.0000 │ Tue 25 Apr 11:00 2023 │ 5m0s           │
+	//└────┴───┴────────────┴────────────────┴───────────────────────┴────────────────┘
+}
@@ -17,6 +17,7 @@ type LastExperimentLogCommand struct {
 	Log           bool `short:"l" long:"log" description:"print artemis logs"`
 	Stderr        bool `short:"e" long:"stderr" description:"print artemis stderr (for checking segfaults)"`
 	Configuration bool `short:"c" long:"configuration" description:"print the experiment configuration"`
+	Explain       bool `long:"explain" description:"print which configuration layer supplied each field"`
 }
 
 var lastExperimentCommand = &LastExperimentLogCommand{}
@@ -38,6 +39,10 @@ func (c *LastExperimentLogCommand) printSummary(name string, log *letopb.Experim
 	fmt.Printf("Start Date : %s\n", start.Local().Format(timeFmt))
 	fmt.Printf("End Date   : %s\n", end.Local().Format(timeFmt))
 	fmt.Printf("Duration   : %s\n", humanize.Duration(ellapsed))
+	for _, p := range log.Pauses {
+		pauseStart := p.Start.AsTime()
+		fmt.Printf("Paused     : %s for %s\n", pauseStart.Local().Format(timeFmt), humanize.Duration(p.End.AsTime().Sub(pauseStart)))
+	}
 	fmt.Printf("Status     : %s\n", status)
 	if log.HasError == true {
 		fmt.Printf("Error      : %s\n", log.Error)
@@ -45,14 +50,14 @@ func (c *LastExperimentLogCommand) printSummary(name string, log *letopb.Experim
 }
 
 func (c *LastExperimentLogCommand) None() bool {
-	return (c.All || c.Log || c.Configuration || c.Stderr) == false
+	return (c.All || c.Log || c.Configuration || c.Stderr || c.Explain) == false
 }
 
 func (c *LastExperimentLogCommand) MultipleSections() bool {
 	if c.All == true {
 		return true
 	}
-	sections := []bool{c.Configuration, c.Log, c.Stderr}
+	sections := []bool{c.Configuration, c.Explain, c.Log, c.Stderr}
 	count := 0
 	for _, s := range sections {
 		if s == true {
@@ -82,6 +87,12 @@ func (c *LastExperimentLogCommand) printConfiguration(log *letopb.ExperimentLog)
 	c.printFooter("Experiment YAML Configuration")
 }
 
+func (c *LastExperimentLogCommand) printProvenance(log *letopb.ExperimentLog) {
+	c.printHeader("Configuration Provenance")
+	printProvenance(log.YamlConfiguration, log.ConfigurationProvenance)
+	c.printFooter("Configuration Provenance")
+}
+
 func (c *LastExperimentLogCommand) printArtemisLog(log *letopb.ExperimentLog) {
 	c.printHeader("Artemis INFO Log")
 	fmt.Println(log.Log)
@@ -127,6 +138,10 @@ func (c *LastExperimentLogCommand) printLog(log *letopb.ExperimentLog,
 		c.printConfiguration(log)
 	}
 
+	if c.All || c.Explain {
+		c.printProvenance(log)
+	}
+
 	if c.All || c.Log {
 		c.printArtemisLog(log)
 	}
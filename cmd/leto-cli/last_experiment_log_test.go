@@ -34,6 +34,28 @@ func ExampleLastExperimentLogCommand_summary() {
 	//Error      : Something critical happened
 }
 
+func ExampleLastExperimentLogCommand_pauses() {
+	log := &letopb.ExperimentLog{
+		ExperimentDir: "someexp.0003",
+		Start:         timestamppb.New(time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)),
+		End:           timestamppb.New(time.Date(2023, 4, 1, 16, 0, 0, 0, time.UTC)),
+		Pauses: []*letopb.ExperimentPause{
+			{
+				Start: timestamppb.New(time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)),
+				End:   timestamppb.New(time.Date(2023, 4, 1, 12, 20, 0, 0, time.UTC)),
+			},
+		},
+	}
+	(&LastExperimentLogCommand{}).printLog(log, testconfig)
+	//Output: Name       : someexp
+	//Output Dir : someexp.0003
+	//Start Date : Saturday  1 Apr 12:00:00 2023
+	//End Date   : Saturday  1 Apr 18:00:00 2023
+	//Duration   : 6h
+	//Paused     : Saturday  1 Apr 14:00:00 2023 for 20m0s
+	//Status     : [36m✓[m
+}
+
 func ExampleLastExperimentLogCommand_log() {
 	(&LastExperimentLogCommand{Log: true}).printLog(testlog, testconfig)
 	//Output: artemis log
@@ -73,12 +95,56 @@ func ExampleLastExperimentLogCommand_config() {
 	//     max-line-mean-square-error: 10
 	//     min-black-white-diff: 50
 	//     deglitch: false
+	// output:
+	//   period: 2h0m0s
+	//   hermes-max-size-mb: 0
+	//   video-max-size-mb: 0
+	// rotation:
+	//   at: ""
+	//   period: 24h0m0s
+	// broadcast:
+	//   policy: drop-newest
+	//   max-consecutive-drops: 100
+	// synchronization:
+	//   max-clock-drift: 5ms
+	// slave-supervision:
+	//   period: 5s
+	//   give-up-after: 2m0s
+	//   on-failure: continue
+	// sinks: []
 	// highlights: []
 	// load-balancing: null
 	// threads: 0
 	// restart-on-reboot: false
 }
 
+func ExampleLastExperimentLogCommand_explain() {
+	log := &letopb.ExperimentLog{
+		YamlConfiguration: testlog.YamlConfiguration,
+		ConfigurationProvenance: map[string]string{
+			"experiment":       leto.UserLayer,
+			"camera.fps":       leto.SystemLayer,
+			"apriltag.family":  leto.RecommendedLayer,
+			"stream.host":      leto.RecommendedLayer,
+			"highlights":       leto.UserLayer,
+			"rotation.period":  leto.RecommendedLayer,
+			"some.newer.field": leto.NodeLayer,
+		},
+	}
+	(&LastExperimentLogCommand{Explain: true}).printLog(log, testconfig)
+	//Output: ┌──────────────────┬─────────┬───────────────────────┐
+	//│            Field │ Value   │ Source                │
+	//├──────────────────┼─────────┼───────────────────────┤
+	//│       experiment │ someexp │ user                  │
+	//│      stream.host │         │ recommended           │
+	//│       camera.fps │ 8       │ /etc/default/leto.yml │
+	//│  apriltag.family │         │ recommended           │
+	//│  rotation.period │ 24h0m0s │ recommended           │
+	//│       highlights │ []      │ user                  │
+	//│ some.newer.field │ ~       │ node                  │
+	//└──────────────────┴─────────┴───────────────────────┘
+}
+
 func ExampleLastExperimentLogCommand_all() {
 	(&LastExperimentLogCommand{All: true}).printLog(testlog, testconfig)
 	//Output: Name       : someexp
@@ -118,6 +184,23 @@ func ExampleLastExperimentLogCommand_all() {
 	//     max-line-mean-square-error: 10
 	//     min-black-white-diff: 50
 	//     deglitch: false
+	// output:
+	//   period: 2h0m0s
+	//   hermes-max-size-mb: 0
+	//   video-max-size-mb: 0
+	// rotation:
+	//   at: ""
+	//   period: 24h0m0s
+	// broadcast:
+	//   policy: drop-newest
+	//   max-consecutive-drops: 100
+	// synchronization:
+	//   max-clock-drift: 5ms
+	// slave-supervision:
+	//   period: 5s
+	//   give-up-after: 2m0s
+	//   on-failure: continue
+	// sinks: []
 	// highlights: []
 	// load-balancing: null
 	// threads: 0
@@ -125,6 +208,12 @@ func ExampleLastExperimentLogCommand_all() {
 	//
 	// === End of Experiment YAML Configuration ===
 	//
+	// === Configuration Provenance ===
+	//
+	// No configuration provenance recorded
+	//
+	// === End of Configuration Provenance ===
+	//
 	// === Artemis INFO Log ===
 	//
 	// artemis log
@@ -139,7 +228,10 @@ func ExampleLastExperimentLogCommand_all() {
 }
 
 func init() {
-	merged := leto.LoadDefaultConfig()
+	merged, err := leto.LoadDefaultConfig()
+	if err != nil {
+		panic(err)
+	}
 	merged.Merge(&testconfig)
 	testconfig = *merged
 
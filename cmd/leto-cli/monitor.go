@@ -2,17 +2,20 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
 	"gopkg.in/yaml.v2"
 )
 
+// REFRESH_DURATION is the period at which new nodes are looked for
+// on the network. Statuses of known nodes are pushed by the nodes.
 var REFRESH_DURATION = 1 * time.Minute
 
 type MonitorCommand struct {
@@ -20,95 +23,81 @@ type MonitorCommand struct {
 
 	lister *leto.NodeLister
 
+	watched         map[string]bool
 	currentStatuses map[string]*leto.TrackingConfiguration
+	updates         chan nodeUpdate
 }
 
 var monitorCommand = &MonitorCommand{}
 
-func (c *MonitorCommand) getStatuses() (map[string]*leto.TrackingConfiguration, error) {
+// A nodeUpdate is a status pushed by a node, or the end of its status
+// stream.
+type nodeUpdate struct {
+	Instance string
+	// Config is the configuration of the running experiment, or nil
+	// if the node is idle.
+	Config *leto.TrackingConfiguration
+	// Ended is set once the status stream of the node ended, with
+	// Error if it failed.
+	Ended bool
+	Error error
+}
+
+// watchNewNodes starts watching the status of any node not yet
+// watched.
+func (c *MonitorCommand) watchNewNodes() error {
 	nodes, err := c.lister.ListNodes()
 	if err != nil {
-		return nil, err
-	}
-	wg := sync.WaitGroup{}
-	type Result struct {
-		Instance string
-		Config   *leto.TrackingConfiguration
-		Error    error
+		return err
 	}
-	results := make(chan Result, 20)
 	for _, nLocal := range nodes {
 		n := nLocal
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-
-			status, err := n.GetStatus()
-			if err != nil {
-				results <- Result{Instance: n.Name, Config: nil, Error: err}
-				return
-			}
-
-			if status.Experiment == nil {
-				results <- Result{Instance: n.Name, Config: nil, Error: nil}
-				return
-			}
-
-			config := leto.TrackingConfiguration{}
-			err = yaml.Unmarshal([]byte(status.Experiment.YamlConfiguration), &config)
-
-			if err != nil {
-				results <- Result{Instance: n.Name, Config: nil, Error: err}
-				return
-			}
-
-			results <- Result{Instance: n.Name, Config: &config, Error: nil}
-
-		}()
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	res := make(map[string]*leto.TrackingConfiguration)
-	for r := range results {
-		if r.Error != nil {
-			log.Printf("Could not fetch status for '%s': %s", r.Instance, r.Error)
-		} else {
-			res[r.Instance] = r.Config
+		if c.watched[n.Name] == true {
+			continue
 		}
+		c.watched[n.Name] = true
+		go c.watch(n)
 	}
-
-	return res, nil
+	return nil
 }
 
-func (c *MonitorCommand) buildEvents() ([]string, error) {
-	newStatuses, err := c.getStatuses()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		c.currentStatuses = newStatuses
-	}()
-
-	events := []string{}
-	for nodeName, config := range c.currentStatuses {
-		newConfig, ok := newStatuses[nodeName]
-		if ok == false {
-			if config != nil {
-				events = append(events, fmt.Sprintf(":warning: Experiment `%s` on *%s* apparently ended unexpectedly", config.ExperimentName, nodeName))
-			}
-			continue
+func (c *MonitorCommand) watch(n leto.Node) {
+	err := n.WatchStatus(context.Background(), func(status *letopb.Status) error {
+		if status.Experiment == nil {
+			c.updates <- nodeUpdate{Instance: n.Name}
+			return nil
+		}
+		config := leto.TrackingConfiguration{}
+		if err := yaml.Unmarshal([]byte(status.Experiment.YamlConfiguration), &config); err != nil {
+			return err
 		}
+		c.updates <- nodeUpdate{Instance: n.Name, Config: &config}
+		return nil
+	})
+	c.updates <- nodeUpdate{Instance: n.Name, Ended: true, Error: err}
+}
 
-		if newConfig == nil && config != nil {
-			events = append(events, fmt.Sprintf(":information_source: Experiment `%s` on *%s* ended hopefully gracefully", config.ExperimentName, nodeName))
+// buildEvents returns the events to report for update.
+func (c *MonitorCommand) buildEvents(update nodeUpdate) []string {
+	config, known := c.currentStatuses[update.Instance]
+	if update.Ended == true {
+		// the node will be watched again once found on the network.
+		delete(c.watched, update.Instance)
+		delete(c.currentStatuses, update.Instance)
+		if update.Error != nil {
+			log.Printf("Could not watch status of '%s': %s", update.Instance, update.Error)
+		}
+		if config != nil {
+			return []string{fmt.Sprintf(":warning: Experiment `%s` on *%s* apparently ended unexpectedly", config.ExperimentName, update.Instance)}
 		}
+		return nil
 	}
 
-	return events, nil
+	c.currentStatuses[update.Instance] = update.Config
+	if known == true && update.Config == nil && config != nil {
+		return []string{fmt.Sprintf(":information_source: Experiment `%s` on *%s* ended hopefully gracefully", config.ExperimentName, update.Instance)}
+	}
+	return nil
 }
 
 func encodeMessage(message string) (*bytes.Buffer, error) {
@@ -142,26 +131,36 @@ func (c *MonitorCommand) postToSlack(message string) error {
 
 func (c *MonitorCommand) Execute(args []string) error {
 	c.lister = leto.NewNodeLister()
-	c.currentStatuses = nil
+	c.watched = make(map[string]bool)
+	c.currentStatuses = make(map[string]*leto.TrackingConfiguration)
+	c.updates = make(chan nodeUpdate)
+
+	ticker := time.NewTicker(REFRESH_DURATION)
+	defer ticker.Stop()
 	for {
-		events, err := c.buildEvents()
-		if err != nil {
-			if err = c.postToSlack(fmt.Sprintf("[CRITICAL]: Could not build events: %s", err)); err != nil {
+		if err := c.watchNewNodes(); err != nil {
+			if err = c.postToSlack(fmt.Sprintf("[CRITICAL]: Could not list nodes: %s", err)); err != nil {
 				log.Printf("Could not post to slack: %s", err)
 			}
 		}
-		for _, e := range events {
-			if err := c.postToSlack(e); err != nil {
-				log.Printf("Could not post to slack: %s", err)
+
+		for refresh := false; refresh == false; {
+			select {
+			case <-ticker.C:
+				refresh = true
+			case update := <-c.updates:
+				for _, e := range c.buildEvents(update) {
+					if err := c.postToSlack(e); err != nil {
+						log.Printf("Could not post to slack: %s", err)
+					}
+				}
 			}
 		}
-
-		time.Sleep(REFRESH_DURATION)
 	}
 
 }
 
 func init() {
-	parser.AddCommand("monitor", "monitors (scarcefully) local network for leto instances", "Monitor every minute the status of nodes on the network and report terminated experiment on slack", monitorCommand)
+	parser.AddCommand("monitor", "monitors local network for leto instances", "Watch the status of nodes on the network, looking for new nodes every minute, and report terminated experiment on slack", monitorCommand)
 
 }
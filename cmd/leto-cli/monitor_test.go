@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+)
+
+func ExampleMonitorCommand() {
+	c := &MonitorCommand{
+		watched:         map[string]bool{"leto.athens": true, "leto.sparta": true},
+		currentStatuses: map[string]*leto.TrackingConfiguration{},
+	}
+	for _, u := range []nodeUpdate{
+		{Instance: "leto.athens", Config: &leto.TrackingConfiguration{ExperimentName: "ants"}},
+		{Instance: "leto.sparta", Config: &leto.TrackingConfiguration{ExperimentName: "bees"}},
+		{Instance: "leto.athens"},
+		{Instance: "leto.athens"},
+		{Instance: "leto.sparta", Ended: true, Error: errors.New("connection reset")},
+	} {
+		for _, e := range c.buildEvents(u) {
+			fmt.Println(e)
+		}
+	}
+	fmt.Println(c.watched)
+	// Output:
+	// :information_source: Experiment `ants` on *leto.athens* ended hopefully gracefully
+	// :warning: Experiment `bees` on *leto.sparta* apparently ended unexpectedly
+	// map[leto.athens:true]
+}
@@ -106,7 +106,8 @@ func startTracking(name Nodename, config leto.TrackingConfiguration) error {
 	}
 	request := &letopb.StartRequest{YamlConfiguration: string(asYaml)}
 
-	return node.StartTracking(request)
+	_, err = node.StartTracking(request)
+	return err
 }
 
 func (c *SaveNetworkStateCommand) Execute(args []string) error {
@@ -0,0 +1,22 @@
+package main
+
+type PauseCommand struct {
+	Args struct {
+		Node Nodename
+	} `positional-args:"yes" required:"yes"`
+}
+
+var pauseCommand = &PauseCommand{}
+
+func (c *PauseCommand) Execute([]string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	return n.PauseTracking()
+}
+
+func init() {
+	parser.AddCommand("pause", "pauses tracking on a specified node", "Pauses the tracking on a specified master node. The experiment continues in the same output directory once resumed", pauseCommand)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/atuleu/go-tablifier"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/jessevdk/go-flags"
+	"gopkg.in/yaml.v2"
+)
+
+type PresetCommand struct{}
+
+type SavePresetCommand struct {
+	Config leto.TrackingConfiguration
+
+	Args struct {
+		Node       Nodename
+		Name       string
+		ConfigFile flags.Filename
+	} `positional-args:"yes"`
+}
+
+type ListPresetsCommand struct {
+	Args struct {
+		Node Nodename
+	} `positional-args:"yes" required:"yes"`
+}
+
+type ShowPresetCommand struct {
+	Args struct {
+		Node Nodename
+		Name string
+	} `positional-args:"yes" required:"yes"`
+}
+
+type DeletePresetCommand struct {
+	Args struct {
+		Node Nodename
+		Name string
+	} `positional-args:"yes" required:"yes"`
+}
+
+var presetCommand = &PresetCommand{}
+var savePresetCommand = &SavePresetCommand{}
+var listPresetsCommand = &ListPresetsCommand{}
+var showPresetCommand = &ShowPresetCommand{}
+var deletePresetCommand = &DeletePresetCommand{}
+
+func (c *SavePresetCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+	if len(c.Args.Name) == 0 {
+		return fmt.Errorf("Missing mandatory preset name")
+	}
+
+	asYaml, err := buildConfiguration(&c.Config, c.Args.ConfigFile, n)
+	if err != nil {
+		return err
+	}
+	return n.SavePreset(&letopb.Preset{
+		Name:              c.Args.Name,
+		YamlConfiguration: string(asYaml),
+	})
+}
+
+type PresetTableLine struct {
+	Name       string
+	Experiment string
+	Family     string
+	FPS        string
+}
+
+func (c *ListPresetsCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	list, err := n.ListPresets()
+	if err != nil {
+		return err
+	}
+	c.printList(list.Presets)
+	return nil
+}
+
+func (c *ListPresetsCommand) printList(presets []*letopb.Preset) {
+	lines := make([]PresetTableLine, 0, len(presets))
+	for _, p := range presets {
+		config := leto.TrackingConfiguration{}
+		yaml.Unmarshal([]byte(p.YamlConfiguration), &config)
+
+		line := PresetTableLine{
+			Name:       p.Name,
+			Experiment: config.ExperimentName,
+			Family:     "default",
+			FPS:        "default",
+		}
+		if config.Detection.Family != nil {
+			line.Family = *config.Detection.Family
+		}
+		if config.Camera.FPS != nil {
+			line.FPS = fmt.Sprintf("%g", *config.Camera.FPS)
+		}
+		lines = append(lines, line)
+	}
+
+	tablifier.Tablify(lines)
+}
+
+func (c *ShowPresetCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	preset, err := n.GetPreset(c.Args.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Print(preset.YamlConfiguration)
+	return nil
+}
+
+func (c *DeletePresetCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+	return n.DeletePreset(c.Args.Name)
+}
+
+func init() {
+	cmd, err := parser.AddCommand("preset",
+		"manages configuration presets of a node",
+		"Manages the named tracking configurations stored on a node, which could be used with 'start --preset'",
+		presetCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("save",
+		"saves a preset on a node",
+		"Saves, or replaces, a named configuration on a node, built from command line options or a yaml configFile",
+		savePresetCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("list",
+		"lists the presets of a node",
+		"Lists all presets stored on a node",
+		listPresetsCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("show",
+		"displays a preset",
+		"Displays the configuration of a preset stored on a node",
+		showPresetCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("delete",
+		"deletes a preset",
+		"Deletes a preset stored on a node",
+		deletePresetCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
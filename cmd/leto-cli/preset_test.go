@@ -0,0 +1,25 @@
+package main
+
+import "github.com/formicidae-tracker/leto/pkg/letopb"
+
+func ExampleListPresetsCommand() {
+	presets := []*letopb.Preset{
+		{
+			Name:              "bees-36h11-8fps",
+			YamlConfiguration: "experiment: bees\ncamera:\n  fps: 8\napriltag:\n  family: 36h11\n",
+		},
+		{
+			Name:              "ants-highlights",
+			YamlConfiguration: "highlights: [1, 2]\n",
+		},
+	}
+
+	(&ListPresetsCommand{}).printList(presets)
+	//output:
+	//┌─────────────────┬────────────┬─────────┬─────────┐
+	//│            Name │ Experiment │ Family  │ FPS     │
+	//├─────────────────┼────────────┼─────────┼─────────┤
+	//│ bees-36h11-8fps │ bees       │ 36h11   │ 8       │
+	//│ ants-highlights │            │ default │ default │
+	//└─────────────────┴────────────┴─────────┴─────────┘
+}
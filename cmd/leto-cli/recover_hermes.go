@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/jessevdk/go-flags"
+)
+
+type RecoverHermesCommand struct {
+	Args struct {
+		Directory flags.Filename
+	} `positional-args:"yes" required:"yes"`
+}
+
+var recoverHermesCommand = &RecoverHermesCommand{}
+
+func (c *RecoverHermesCommand) Execute(args []string) error {
+	recovered, err := leto.RecoverHermesDirectory(string(c.Args.Directory))
+	for _, r := range recovered {
+		status := "complete"
+		if r.Truncated == true {
+			status = "truncated"
+		}
+		next := r.Next
+		if len(next) == 0 {
+			next = "none"
+		}
+		fmt.Printf("Recovered %s: %d frames (%s), next segment: %s\n", r.Segment, r.Frames, status, next)
+	}
+	if err != nil {
+		return err
+	}
+	if len(recovered) == 0 {
+		fmt.Printf("No orphaned uncompressed segment in %s\n", c.Args.Directory)
+	}
+	return nil
+}
+
+func init() {
+	_, err := parser.AddCommand("recover-hermes",
+		"recovers hermes tracking files left unclosed by a crash",
+		"Rebuilds valid hermes tracking segments from the uncompressed segments left in an experiment directory after a crash or a power loss. It must not be used on the directory of a running experiment",
+		recoverHermesCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+type ResumeCommand struct {
+	Args struct {
+		Node Nodename
+	} `positional-args:"yes" required:"yes"`
+}
+
+var resumeCommand = &ResumeCommand{}
+
+func (c *ResumeCommand) Execute([]string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	return n.ResumeTracking()
+}
+
+func init() {
+	parser.AddCommand("resume", "resumes tracking on a specified node", "Resumes a paused tracking on a specified node", resumeCommand)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atuleu/go-tablifier"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/jessevdk/go-flags"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v2"
+)
+
+type ScheduleCommand struct{}
+
+type AddScheduleCommand struct {
+	Config leto.TrackingConfiguration
+
+	StartAt  string        `long:"start-at" description:"time to start the experiment, as '15:04', '2006-01-02 15:04' or RFC3339" required:"yes"`
+	StopAt   string        `long:"stop-at" description:"time to stop the experiment, same format as --start-at"`
+	Duration time.Duration `long:"duration" description:"duration of the experiment, exclusive with --stop-at"`
+
+	Args struct {
+		Node       Nodename
+		ConfigFile flags.Filename
+	} `positional-args:"yes"`
+}
+
+type ListSchedulesCommand struct {
+	Args struct {
+		Node Nodename
+	} `positional-args:"yes" required:"yes"`
+}
+
+type CancelScheduleCommand struct {
+	Args struct {
+		Node Nodename
+		ID   int32
+	} `positional-args:"yes" required:"yes"`
+}
+
+var scheduleCommand = &ScheduleCommand{}
+var addScheduleCommand = &AddScheduleCommand{}
+var listSchedulesCommand = &ListSchedulesCommand{}
+var cancelScheduleCommand = &CancelScheduleCommand{}
+
+// parseScheduleTime parses a time either as a full RFC3339 time, a
+// local date and time, or a local time of day. In the later case,
+// the next occurrence of that time after now is returned.
+func parseScheduleTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, now.Location()); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("15:04", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s': expected '15:04', '2006-01-02 15:04' or RFC3339", value)
+	}
+	res := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if res.After(now) == false {
+		res = res.AddDate(0, 0, 1)
+	}
+	return res, nil
+}
+
+func (c *AddScheduleCommand) buildRequest(now time.Time, n *leto.Node) (*letopb.ScheduleRequest, error) {
+	if len(c.StopAt) > 0 && c.Duration != 0 {
+		return nil, errors.New("--stop-at and --duration are mutually exclusive")
+	}
+
+	start, err := parseScheduleTime(c.StartAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &letopb.ScheduleRequest{
+		Start: timestamppb.New(start),
+	}
+
+	if len(c.StopAt) > 0 {
+		end, err := parseScheduleTime(c.StopAt, start)
+		if err != nil {
+			return nil, err
+		}
+		request.End = timestamppb.New(end)
+	} else if c.Duration > 0 {
+		request.End = timestamppb.New(start.Add(c.Duration))
+	}
+
+	asYaml, err := buildConfiguration(&c.Config, c.Args.ConfigFile, n)
+	if err != nil {
+		return nil, err
+	}
+	request.YamlConfiguration = string(asYaml)
+
+	return request, nil
+}
+
+func (c *AddScheduleCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	request, err := c.buildRequest(time.Now(), n)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := n.ScheduleTracking(request)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Scheduled experiment %d on %s\n", schedule.Id, n.Name)
+	return nil
+}
+
+type ScheduleTableLine struct {
+	ID         int32
+	Experiment string
+	Start      string
+	Stop       string
+	State      string
+}
+
+func (c *ListSchedulesCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	list, err := n.ListSchedules()
+	if err != nil {
+		return err
+	}
+	c.printList(list.Schedules)
+	return nil
+}
+
+func (c *ListSchedulesCommand) printList(schedules []*letopb.ScheduledExperiment) {
+	lines := make([]ScheduleTableLine, 0, len(schedules))
+
+	timeFmt := "Mon _2 Jan 15:04 2006"
+
+	for _, s := range schedules {
+		config := leto.TrackingConfiguration{}
+		yaml.Unmarshal([]byte(s.YamlConfiguration), &config)
+
+		stop := "never"
+		if s.End != nil {
+			stop = s.End.AsTime().Local().Format(timeFmt)
+		}
+		state := "pending"
+		if s.Started == true {
+			state = "started"
+		}
+
+		lines = append(lines, ScheduleTableLine{
+			ID:         s.Id,
+			Experiment: config.ExperimentName,
+			Start:      s.Start.AsTime().Local().Format(timeFmt),
+			Stop:       stop,
+			State:      state,
+		})
+	}
+
+	tablifier.Tablify(lines)
+}
+
+func (c *CancelScheduleCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+	return n.CancelSchedule(c.Args.ID)
+}
+
+func init() {
+	cmd, err := parser.AddCommand("schedule",
+		"manages scheduled experiments of a node",
+		"Manages the experiments scheduled to start, and optionally stop, at a given time on a node",
+		scheduleCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("add",
+		"schedules an experiment on a specified node",
+		"Schedules an experiment to start, and optionally stop, at a given time on a specified node",
+		addScheduleCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("ls",
+		"lists scheduled experiments on a node",
+		"Lists all pending or started scheduled experiments on a node",
+		listSchedulesCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = cmd.AddCommand("cancel",
+		"cancels a scheduled experiment",
+		"Cancels a scheduled experiment on a node. An already started experiment is not stopped",
+		cancelScheduleCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
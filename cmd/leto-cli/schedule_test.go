@@ -0,0 +1,34 @@
+package main
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ExampleListSchedulesCommand() {
+	schedules := []*letopb.ScheduledExperiment{
+		{
+			Id:                0,
+			YamlConfiguration: "experiment: night-run",
+			Start:             timestamppb.New(time.Date(2023, 4, 1, 20, 0, 0, 0, time.UTC)),
+			End:               timestamppb.New(time.Date(2023, 4, 2, 6, 0, 0, 0, time.UTC)),
+			Started:           true,
+		},
+		{
+			Id:                1,
+			YamlConfiguration: "experiment: long-run",
+			Start:             timestamppb.New(time.Date(2023, 4, 3, 8, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	(&ListSchedulesCommand{}).printList(schedules)
+	//output:
+	//┌────┬────────────┬───────────────────────┬───────────────────────┬─────────┐
+	//│ ID │ Experiment │ Start                 │ Stop                  │ State   │
+	//├────┼────────────┼───────────────────────┼───────────────────────┼─────────┤
+	//│  0 │ night-run  │ Sat  1 Apr 22:00 2023 │ Sun  2 Apr 08:00 2023 │ started │
+	//│  1 │ long-run   │ Mon  3 Apr 10:00 2023 │ never                 │ pending │
+	//└────┴────────────┴───────────────────────┴───────────────────────┴─────────┘
+}
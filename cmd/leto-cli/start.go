@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/formicidae-tracker/leto/internal/leto"
 	"github.com/formicidae-tracker/leto/pkg/letopb"
@@ -10,6 +11,8 @@ import (
 
 type StartCommand struct {
 	Config leto.TrackingConfiguration
+	Preset string `long:"preset" description:"preset stored on the node to use as base configuration"`
+	DryRun bool   `long:"dry-run" description:"only validates the configuration on the node, and prints the final configuration and artemis command"`
 
 	Args struct {
 		Node       Nodename
@@ -25,28 +28,79 @@ func (c *StartCommand) Execute(args []string) error {
 		return err
 	}
 
-	config := &(c.Config)
-	if len(c.Args.ConfigFile) > 0 {
-		fileConfig, err := leto.ReadConfiguration(string(c.Args.ConfigFile))
+	asYaml, err := buildConfiguration(&c.Config, c.Args.ConfigFile, n)
+	if err != nil {
+		return err
+	}
+	request := &letopb.StartRequest{
+		YamlConfiguration: string(asYaml),
+		Preset:            c.Preset,
+	}
+
+	if c.DryRun == true {
+		response, err := n.ValidateConfiguration(request)
 		if err != nil {
 			return err
 		}
-		if err := fileConfig.Merge(config); err != nil {
-			return fmt.Errorf("Could not merge file and commandline configuration: %s", err)
-		}
-		config = fileConfig
+		printValidation(response)
+		return nil
 	}
-	config.Loads = nil
 
-	asYaml, err := config.Yaml()
+	response, err := n.StartTracking(request)
 	if err != nil {
 		return err
 	}
-	request := &letopb.StartRequest{
-		YamlConfiguration: string(asYaml),
+	printStartedNodes(response.Nodes)
+	return nil
+}
+
+func printValidation(response *letopb.ValidationResponse) {
+	fmt.Printf("configuration is valid, experiment would be saved in %s\n", response.ExperimentDir)
+	fmt.Println("final configuration:")
+	fmt.Print(response.YamlConfiguration)
+	fmt.Println("artemis command:")
+	fmt.Println(strings.Join(response.ArtemisCommand, " "))
+}
+
+func printStartedNodes(nodes []*letopb.NodeResult) {
+	for _, node := range nodes {
+		fmt.Printf("%s: started in %s\n", node.Node, node.ExperimentDir)
+	}
+}
+
+// buildConfiguration merges the configuration passed on the command
+// line over the one read from configFile, if any, and returns it as
+// YAML. The bases of configFile are resolved, using the presets of
+// node.
+func buildConfiguration(config *leto.TrackingConfiguration, configFile flags.Filename, node *leto.Node) ([]byte, error) {
+	if len(configFile) > 0 {
+		fileConfig, err := nodeBaseResolver(node).ReadFile(string(configFile))
+		if err != nil {
+			return nil, err
+		}
+		if err := fileConfig.Merge(config); err != nil {
+			return nil, fmt.Errorf("Could not merge file and commandline configuration: %s", err)
+		}
+		config = fileConfig
 	}
+	config.Loads = nil
 
-	return n.StartTracking(request)
+	return config.Yaml()
+}
+
+// nodeBaseResolver resolves base files, and base presets stored on
+// node.
+func nodeBaseResolver(node *leto.Node) leto.BaseResolver {
+	return leto.BaseResolver{
+		Files: true,
+		Preset: func(name string) (*leto.TrackingConfiguration, error) {
+			preset, err := node.GetPreset(name)
+			if err != nil {
+				return nil, err
+			}
+			return leto.ParseConfiguration([]byte(preset.YamlConfiguration))
+		},
+	}
 }
 
 func init() {
@@ -0,0 +1,28 @@
+package main
+
+import "github.com/formicidae-tracker/leto/pkg/letopb"
+
+func ExampleStartCommand() {
+	printStartedNodes([]*letopb.NodeResult{
+		{Node: "master", ExperimentDir: "myexp.0000"},
+		{Node: "slave-a", ExperimentDir: "myexp.0000"},
+	})
+	//output:
+	//master: started in myexp.0000
+	//slave-a: started in myexp.0000
+}
+
+func ExampleStartCommand_dryRun() {
+	printValidation(&letopb.ValidationResponse{
+		YamlConfiguration: "experiment: myexp\nthreads: 4\n",
+		ArtemisCommand:    []string{"artemis", "--host", "localhost", "--at-family", "36h11"},
+		ExperimentDir:     "myexp.0001",
+	})
+	//output:
+	//configuration is valid, experiment would be saved in myexp.0001
+	//final configuration:
+	//experiment: myexp
+	//threads: 4
+	//artemis command:
+	//artemis --host localhost --at-family 36h11
+}
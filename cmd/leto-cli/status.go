@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
 	"gopkg.in/yaml.v2"
 )
 
@@ -11,6 +15,7 @@ type StatusCommand struct {
 	Args struct {
 		Node Nodename
 	} `positional-args:"yes" required:"yes"`
+	Explain bool `long:"explain" description:"print which configuration layer supplied each field"`
 }
 
 var statusCommand = &StatusCommand{}
@@ -44,13 +49,49 @@ func (c *StatusCommand) Execute(args []string) error {
 	}
 
 	fmt.Printf("State: Running Experiment '%s' since %s\n", config.ExperimentName, status.Experiment.Since)
+	if status.Experiment.PausedSince != nil {
+		fmt.Printf("Paused since: %s\n", status.Experiment.PausedSince)
+	}
 	fmt.Printf("Experiment Local Output Directory: %s\n", status.Experiment.ExperimentDir)
+	printDroppedFrames(status.Experiment.DroppedFrames)
+	printClockSynchronization(status.Experiment, time.Now())
 	fmt.Printf("=== Experiment YAML Configuration START ===\n")
 	fmt.Println(status.Experiment.YamlConfiguration)
 	fmt.Printf("=== Experiment YAML Configuration END ===\n")
+	if c.Explain == true {
+		fmt.Printf("=== Experiment Configuration Provenance START ===\n")
+		printProvenance(status.Experiment.YamlConfiguration, status.Experiment.ConfigurationProvenance)
+		fmt.Printf("=== Experiment Configuration Provenance END ===\n")
+	}
 	return nil
 }
 
+func printDroppedFrames(dropped map[string]int64) {
+	if len(dropped) == 0 {
+		return
+	}
+	outputs := make([]string, 0, len(dropped))
+	for o := range dropped {
+		outputs = append(outputs, o)
+	}
+	sort.Strings(outputs)
+	counts := make([]string, 0, len(outputs))
+	for _, o := range outputs {
+		counts = append(counts, fmt.Sprintf("%s: %d", o, dropped[o]))
+	}
+	fmt.Printf("Dropped Frames: %s\n", strings.Join(counts, ", "))
+}
+
+func printClockSynchronization(status *letopb.ExperimentStatus, now time.Time) {
+	if status.LastMasterSync != nil {
+		fmt.Printf("Last Master Sync: %s ago\n", now.Sub(status.LastMasterSync.AsTime()).Round(time.Millisecond))
+	}
+	for _, c := range status.ClockOffsets {
+		fmt.Printf("Clock Offset %s: %.1fus, drift: %+.1fus, jitter: %.1fus\n",
+			c.Producer, c.OffsetUs, c.DriftUs, c.JitterUs)
+	}
+}
+
 func init() {
 	_, err := parser.AddCommand("status", "queries the full status on a speciied node", "Queries the complete status on a specified node", statusCommand)
 	if err != nil {
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ExampleStatusCommand_droppedFrames() {
+	printDroppedFrames(map[string]int64{
+		"file":      0,
+		"broadcast": 42,
+	})
+	//output:
+	//Dropped Frames: broadcast: 42, file: 0
+}
+
+func ExampleStatusCommand_clockSynchronization() {
+	now := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	printClockSynchronization(&letopb.ExperimentStatus{
+		LastMasterSync: timestamppb.New(now.Add(-120 * time.Millisecond)),
+		ClockOffsets: []*letopb.ClockSynchronization{
+			{Producer: "slave-a", OffsetUs: 1234.56, DriftUs: -12.3, JitterUs: 4.2},
+			{Producer: "slave-b", OffsetUs: -50.0, DriftUs: 0.4, JitterUs: 1.0},
+		},
+	}, now)
+	//output:
+	//Last Master Sync: 120ms ago
+	//Clock Offset slave-a: 1234.6us, drift: -12.3us, jitter: 4.2us
+	//Clock Offset slave-b: -50.0us, drift: +0.4us, jitter: 1.0us
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/jessevdk/go-flags"
+)
+
+type UpdateCommand struct {
+	Config leto.TrackingConfiguration
+
+	Args struct {
+		Node       Nodename
+		ConfigFile flags.Filename
+	} `positional-args:"yes"`
+}
+
+var updateCommand = &UpdateCommand{}
+
+func (c *UpdateCommand) Execute(args []string) error {
+	n, err := c.Args.Node.GetNode()
+	if err != nil {
+		return err
+	}
+
+	asYaml, err := buildConfiguration(&c.Config, c.Args.ConfigFile, n)
+	if err != nil {
+		return err
+	}
+
+	response, err := n.UpdateConfiguration(&letopb.ConfigurationUpdate{
+		YamlConfiguration: string(asYaml),
+	})
+	if err != nil {
+		return err
+	}
+	printChanges(response.Changes)
+	return nil
+}
+
+func printChanges(changes []*letopb.ConfigurationChange) {
+	if len(changes) == 0 {
+		fmt.Println("configuration unchanged")
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("%s: %s -> %s\n", c.Path, c.Previous, c.Value)
+	}
+}
+
+func init() {
+	_, err := parser.AddCommand("update",
+		"changes the configuration of a running experiment",
+		"Changes the stream configuration of the experiment running on a node, without restarting it. Other changes are rejected.",
+		updateCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
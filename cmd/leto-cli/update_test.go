@@ -0,0 +1,15 @@
+package main
+
+import "github.com/formicidae-tracker/leto/pkg/letopb"
+
+func ExampleUpdateCommand() {
+	printChanges([]*letopb.ConfigurationChange{
+		{Path: "stream.host", Previous: "olympus.local", Value: "backup.local"},
+		{Path: "stream.bitrate", Previous: "2000", Value: "4000"},
+	})
+	printChanges(nil)
+	//output:
+	//stream.host: olympus.local -> backup.local
+	//stream.bitrate: 2000 -> 4000
+	//configuration unchanged
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/atuleu/go-humanize"
+	"github.com/atuleu/go-tablifier"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/jessevdk/go-flags"
+)
+
+type VerifyHermesCommand struct {
+	Args struct {
+		Directory flags.Filename
+	} `positional-args:"yes" required:"yes"`
+}
+
+var verifyHermesCommand = &VerifyHermesCommand{}
+
+type HermesSegmentTableLine struct {
+	Segment  string
+	Frames   int
+	Start    string
+	Duration string
+	Dropped  string
+	Timeouts int
+	Status   string
+}
+
+func (c *VerifyHermesCommand) Execute(args []string) error {
+	report, err := leto.VerifyHermesDirectory(string(c.Args.Directory))
+	if err != nil {
+		return err
+	}
+
+	c.printReport(report)
+
+	if report.Ok() == false {
+		return fmt.Errorf("'%s' contains damaged hermes segments", c.Args.Directory)
+	}
+	return nil
+}
+
+func segmentStatus(s *leto.HermesSegmentReport) string {
+	switch {
+	case s.HasHeader == false:
+		return "\033[1;31m⚠\033[m no header: " + s.TruncationError
+	case s.Truncated == true:
+		return "\033[1;31m⚠\033[m truncated " + s.TruncationError
+	case s.HasFooter == false:
+		return "\033[1;31m⚠\033[m no footer"
+	}
+	return "\033[1;92m✓\033[m"
+}
+
+func (c *VerifyHermesCommand) printReport(report *leto.HermesReport) {
+	lines := make([]HermesSegmentTableLine, 0, len(report.Segments))
+
+	timeFmt := "Mon _2 Jan 15:04:05 2006"
+
+	for _, s := range report.Segments {
+		line := HermesSegmentTableLine{
+			Segment:  s.Filename,
+			Frames:   s.Frames,
+			Start:    "-",
+			Duration: "-",
+			Dropped:  fmt.Sprintf("%d (%.2f%%)", s.DroppedFrames, 100.0*s.DroppedRatio()),
+			Timeouts: s.TimeoutFrames,
+			Status:   segmentStatus(s),
+		}
+		if s.Start.IsZero() == false {
+			line.Start = s.Start.Local().Format(timeFmt)
+			line.Duration = humanize.Duration(s.End.Sub(s.Start).Round(time.Second)).String()
+		}
+		lines = append(lines, line)
+	}
+
+	tablifier.Tablify(lines)
+
+	fmt.Printf("Total frames: %d\n", report.Frames())
+	for _, e := range report.ChainErrors {
+		fmt.Printf("Broken chain: %s\n", e)
+	}
+}
+
+func init() {
+	_, err := parser.AddCommand("verify-hermes",
+		"verifies hermes tracking files of an experiment directory",
+		"Verifies the integrity of all hermes tracking segments in an experiment directory, and reports their content",
+		verifyHermesCommand)
+	if err != nil {
+		panic(err.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+)
+
+func ExampleVerifyHermesCommand() {
+	start := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	report := &leto.HermesReport{
+		Segments: []*leto.HermesSegmentReport{
+			{
+				Filename:      "tracking.0000.hermes",
+				HasHeader:     true,
+				HasFooter:     true,
+				Next:          "tracking.0001.hermes",
+				Frames:        57598,
+				DroppedFrames: 402,
+				TimeoutFrames: 3,
+				Start:         start,
+				End:           start.Add(2 * time.Hour),
+			},
+			{
+				Filename:        "tracking.0001.hermes",
+				HasHeader:       true,
+				Previous:        "tracking.0000.hermes",
+				Frames:          1200,
+				Start:           start.Add(2 * time.Hour),
+				End:             start.Add(2*time.Hour + 150*time.Second),
+				Truncated:       true,
+				TruncationError: "after frame 58799: unexpected EOF",
+			},
+		},
+	}
+
+	(&VerifyHermesCommand{}).printReport(report)
+	//output:
+	//┌──────────────────────┬────────┬──────────────────────────┬──────────┬─────────────┬──────────┬───────────────────────────────────────────────┐
+	//│              Segment │ Frames │ Start                    │ Duration │ Dropped     │ Timeouts │ Status                                        │
+	//├──────────────────────┼────────┼──────────────────────────┼──────────┼─────────────┼──────────┼───────────────────────────────────────────────┤
+	//│ tracking.0000.hermes │ 57598  │ Sat  1 Apr 12:00:00 2023 │ 2h       │ 402 (0.69%) │ 3        │ [1;92m✓[m                                             │
+	//│ tracking.0001.hermes │ 1200   │ Sat  1 Apr 14:00:00 2023 │ 2m30s    │ 0 (0.00%)   │ 0        │ [1;31m⚠[m truncated after frame 58799: unexpected EOF │
+	//└──────────────────────┴────────┴──────────────────────────┴──────────┴─────────────┴──────────┴───────────────────────────────────────────────┘
+	//Total frames: 58798
+}
@@ -14,22 +14,25 @@ type ArtemisListener interface {
 }
 
 type artemisListener struct {
-	outbound chan *hermes.FrameReadout
-	server   *Server
+	outbound      chan *hermes.FrameReadout
+	server        *Server
+	frameIDOffset int64
 }
 
 // Returns an ArtemisListener that listen for any incoming
 // hermes.FrameReadout stream on port, and provide an outbound
-// channel. Cancelling the provided context will gracefully stop the
-// Listener and incoming connections.
-func NewArtemisListener(ctx context.Context, port int) (ArtemisListener, error) {
+// channel. frameIDOffset is added to the ID of each frame. Cancelling
+// the provided context will gracefully stop the Listener and incoming
+// connections.
+func NewArtemisListener(ctx context.Context, port int, frameIDOffset int64) (ArtemisListener, error) {
 	server, err := NewServer(ctx, port, "artemis-in", 100*time.Millisecond)
 	if err != nil {
 		return nil, err
 	}
 	l := &artemisListener{
-		outbound: make(chan *hermes.FrameReadout),
-		server:   server,
+		outbound:      make(chan *hermes.FrameReadout),
+		server:        server,
+		frameIDOffset: frameIDOffset,
 	}
 	l.server.onAccept = l.onAccept
 
@@ -54,6 +57,27 @@ func (l *artemisListener) onAccept(ctx context.Context, conn net.Conn) {
 			logger.WithError(err).Error("frame reading error")
 		}
 	}()
-	ReadAllFrameReadout(ctx, conn, l.outbound, errors)
+	readouts := l.outbound
+	if l.frameIDOffset != 0 {
+		readouts = l.offsetFrameIDs(ctx)
+		defer close(readouts)
+	}
+	ReadAllFrameReadout(ctx, conn, readouts, errors)
 	logger.Info("stop reading incoming frames")
 }
+
+// offsetFrameIDs returns a channel forwarding frames to the outbound
+// channel, with frameIDOffset added to their ID.
+func (l *artemisListener) offsetFrameIDs(ctx context.Context) chan *hermes.FrameReadout {
+	res := make(chan *hermes.FrameReadout)
+	go func() {
+		for m := range res {
+			m.FrameID += l.frameIDOffset
+			select {
+			case l.outbound <- m:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return res
+}
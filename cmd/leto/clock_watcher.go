@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	olympuspb "github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A clockWatcher periodically checks the clock synchronization of
+// the slaves, and raises an alarm when the offset of a slave drifts
+// beyond the configured maximum.
+type clockWatcher struct {
+	ctx      context.Context
+	balance  *WorkloadBalance
+	maxDrift time.Duration
+	olympus  OlympusTask
+	period   time.Duration
+	alarms   map[string]olympuspb.AlarmStatus
+	logger   *logrus.Entry
+}
+
+func NewClockWatcher(ctx context.Context, env *TrackingEnvironment, olympus OlympusTask) Task {
+	return &clockWatcher{
+		ctx:      ctx,
+		balance:  env.Balancing,
+		maxDrift: *env.Config.Synchronization.MaxClockDrift,
+		olympus:  olympus,
+		period:   5 * time.Second,
+		alarms:   make(map[string]olympuspb.AlarmStatus),
+		logger:   tm.NewLogger("clock-watcher").WithContext(ctx),
+	}
+}
+
+func (w *clockWatcher) Run() error {
+	ticker := time.NewTicker(w.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			w.check(now)
+		}
+	}
+}
+
+func (w *clockWatcher) check(now time.Time) {
+	_, producers := w.balance.Synchronization()
+	for _, p := range producers {
+		update := w.buildAlarmUpdate(p, now)
+		if update == nil {
+			continue
+		}
+		if update.Status == olympuspb.AlarmStatus_ON {
+			w.logger.WithFields(logrus.Fields{
+				"producer": p.Producer,
+				"driftUS":  p.Drift,
+			}).Warn("slave clock is drifting")
+		} else {
+			w.logger.WithField("producer", p.Producer).Info("slave clock is synchronized again")
+		}
+		if w.olympus != nil {
+			w.olympus.PushAlarm(update)
+		}
+	}
+}
+
+// buildAlarmUpdate returns the alarm update for p, or nil if its
+// alarm did not change.
+func (w *clockWatcher) buildAlarmUpdate(p ProducerSynchronization, now time.Time) *olympuspb.AlarmUpdate {
+	update := &olympuspb.AlarmUpdate{
+		Identification: "tracking.clock_drift." + p.Producer,
+		Status:         olympuspb.AlarmStatus_OFF,
+		Level:          olympuspb.AlarmLevel_WARNING,
+		Time:           timestamppb.New(now),
+	}
+	drift := time.Duration(p.Drift * float64(time.Microsecond))
+	if math.Abs(float64(drift)) > float64(w.maxDrift) {
+		update.Status = olympuspb.AlarmStatus_ON
+		update.Description = fmt.Sprintf("clock of %s drifted by %s from the master (maximum: %s)",
+			p.Producer, drift.Round(time.Microsecond), w.maxDrift)
+	}
+
+	last, ok := w.alarms[p.Producer]
+	if ok == false {
+		last = olympuspb.AlarmStatus_OFF
+	}
+	w.alarms[p.Producer] = update.Status
+	if last == update.Status {
+		return nil
+	}
+	return update
+}
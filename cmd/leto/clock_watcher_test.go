@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/formicidae-tracker/leto/cmd/leto/mock_main"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	olympuspb "github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/golang/mock/gomock"
+	. "gopkg.in/check.v1"
+)
+
+type ClockWatcherSuite struct {
+	ctrl    *gomock.Controller
+	olympus *mock_main.MockOlympusTask
+	balance *WorkloadBalance
+	watcher *clockWatcher
+}
+
+var _ = Suite(&ClockWatcherSuite{})
+
+func (s *ClockWatcherSuite) SetUpTest(c *C) {
+	s.ctrl = gomock.NewController(c)
+	s.olympus = mock_main.NewMockOlympusTask(s.ctrl)
+	s.balance = &WorkloadBalance{
+		Stride:     2,
+		MasterUUID: "m",
+		Hostnames:  map[string]string{"m": "localhost", "s": "slave"},
+		IDsByUUID: map[string][]bool{
+			"m": {true, false},
+			"s": {false, true},
+		},
+	}
+	c.Assert(s.balance.Check(), IsNil)
+	config := leto.RecommendedSynchronizationConfiguration()
+	s.watcher = NewClockWatcher(context.Background(), &TrackingEnvironment{
+		Balancing: s.balance,
+		Config:    &leto.TrackingConfiguration{Synchronization: config},
+	}, s.olympus).(*clockWatcher)
+}
+
+func (s *ClockWatcherSuite) TearDownTest(c *C) {
+	s.ctrl.Finish()
+}
+
+func (s *ClockWatcherSuite) setOffset(offset float64) {
+	if _, ok := s.balance.initialOffsets["s"]; ok == false {
+		s.balance.initialOffsets["s"] = offset
+	}
+	s.balance.offsets["s"] = offset
+}
+
+func (s *ClockWatcherSuite) TestSynchronization(c *C) {
+	s.balance.lastPoint = &synchronizationPoint{time: time.Unix(10, 0)}
+	s.setOffset(1000.0)
+	s.balance.jitters["s"] = 3.0
+	s.setOffset(1250.0)
+
+	lastSync, producers := s.balance.Synchronization()
+	c.Check(lastSync, Equals, time.Unix(10, 0))
+	c.Check(producers, DeepEquals, []ProducerSynchronization{
+		{Producer: "slave", Offset: 1250.0, Drift: 250.0, Jitter: 3.0},
+	})
+}
+
+func (s *ClockWatcherSuite) TestAlarmOnDrift(c *C) {
+	now := time.Now()
+	s.setOffset(1000.0)
+	// no alarm update while synchronized.
+	s.watcher.check(now)
+
+	s.setOffset(7000.0)
+	s.olympus.EXPECT().PushAlarm(gomock.Any()).Do(func(update *olympuspb.AlarmUpdate) {
+		c.Check(update.Identification, Equals, "tracking.clock_drift.slave")
+		c.Check(update.Status, Equals, olympuspb.AlarmStatus_ON)
+		c.Check(update.Description, Equals, "clock of slave drifted by 6ms from the master (maximum: 5ms)")
+	})
+	s.watcher.check(now)
+	// raised only once.
+	s.watcher.check(now)
+
+	s.setOffset(1100.0)
+	s.olympus.EXPECT().PushAlarm(gomock.Any()).Do(func(update *olympuspb.AlarmUpdate) {
+		c.Check(update.Status, Equals, olympuspb.AlarmStatus_OFF)
+	})
+	s.watcher.check(now)
+}
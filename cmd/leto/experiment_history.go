@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrNoExperiment = errors.New("no experiment run on node")
+
+// experimentHistory stores on disk the letopb.ExperimentLog of every
+// experiment run by the node. Each log is saved in its own file, and
+// identified by the numerical suffix of its filename.
+type experimentHistory struct {
+	mx       sync.Mutex
+	basename string
+}
+
+func newExperimentHistory(dir string) (*experimentHistory, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create history directory: %w", err)
+	}
+	return &experimentHistory{
+		basename: filepath.Join(dir, "experiment.pb"),
+	}, nil
+}
+
+// Add saves log in the history and sets its Id.
+func (h *experimentHistory) Add(log *letopb.ExperimentLog) error {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	filename, id, err := FilenameWithoutOverwrite(h.basename)
+	if err != nil {
+		return fmt.Errorf("could not find unique history name: %w", err)
+	}
+	log.Id = int32(id)
+
+	data, err := proto.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("could not encode experiment log: %w", err)
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
+
+func (h *experimentHistory) Get(id int32) (*letopb.ExperimentLog, error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	return h.get(int(id))
+}
+
+func (h *experimentHistory) get(id int) (*letopb.ExperimentLog, error) {
+	data, err := os.ReadFile(FilenameWithSuffix(h.basename, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w with id %d", ErrNoExperiment, id)
+		}
+		return nil, err
+	}
+	res := &letopb.ExperimentLog{}
+	if err := proto.Unmarshal(data, res); err != nil {
+		return nil, fmt.Errorf("could not decode experiment log %d: %w", id, err)
+	}
+	return res, nil
+}
+
+// ids returns all ids stored in the history in increasing order.
+func (h *experimentHistory) ids() ([]int, error) {
+	ext := filepath.Ext(h.basename)
+	prefix := strings.TrimSuffix(h.basename, ext) + "."
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int, 0, len(matches))
+	for _, m := range matches {
+		id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext))
+		if err != nil {
+			continue
+		}
+		res = append(res, id)
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
+// Last returns the most recent log in the history, or
+// ErrNoExperiment if the history is empty.
+func (h *experimentHistory) Last() (*letopb.ExperimentLog, error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	ids, err := h.ids()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, ErrNoExperiment
+	}
+	return h.get(ids[len(ids)-1])
+}
+
+// List returns all logs in the history, oldest first. The artemis
+// log and stderr are stripped from the result.
+func (h *experimentHistory) List() ([]*letopb.ExperimentLog, error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	ids, err := h.ids()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*letopb.ExperimentLog, 0, len(ids))
+	for _, id := range ids {
+		log, err := h.get(id)
+		if err != nil {
+			return nil, err
+		}
+		log.Log = ""
+		log.Stderr = ""
+		res = append(res, log)
+	}
+	return res, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	. "gopkg.in/check.v1"
+)
+
+type ExperimentHistorySuite struct {
+	history *experimentHistory
+}
+
+var _ = Suite(&ExperimentHistorySuite{})
+
+func (s *ExperimentHistorySuite) SetUpTest(c *C) {
+	var err error
+	s.history, err = newExperimentHistory(c.MkDir())
+	c.Assert(err, IsNil)
+}
+
+func (s *ExperimentHistorySuite) TestEmpty(c *C) {
+	_, err := s.history.Last()
+	c.Check(err, Equals, ErrNoExperiment)
+	_, err = s.history.Get(0)
+	c.Check(err, ErrorMatches, "no experiment run on node with id 0")
+	list, err := s.history.List()
+	c.Check(err, IsNil)
+	c.Check(list, HasLen, 0)
+}
+
+func (s *ExperimentHistorySuite) TestAddGetAndList(c *C) {
+	for _, dir := range []string{"foo.0000", "TEST-MODE.0000", "foo.0001"} {
+		c.Assert(s.history.Add(&letopb.ExperimentLog{
+			ExperimentDir: dir,
+			Log:           "some log",
+			Stderr:        "some stderr",
+		}), IsNil)
+	}
+
+	last, err := s.history.Last()
+	c.Assert(err, IsNil)
+	c.Check(last.Id, Equals, int32(2))
+	c.Check(last.ExperimentDir, Equals, "foo.0001")
+	c.Check(last.Log, Equals, "some log")
+
+	log, err := s.history.Get(1)
+	c.Assert(err, IsNil)
+	c.Check(log.ExperimentDir, Equals, "TEST-MODE.0000")
+
+	list, err := s.history.List()
+	c.Assert(err, IsNil)
+	c.Assert(list, HasLen, 3)
+	for i, l := range list {
+		c.Check(l.Id, Equals, int32(i))
+		c.Check(l.Log, Equals, "")
+		c.Check(l.Stderr, Equals, "")
+	}
+}
+
+func (s *ExperimentHistorySuite) TestPersistsOnDisk(c *C) {
+	c.Assert(s.history.Add(&letopb.ExperimentLog{ExperimentDir: "foo.0000"}), IsNil)
+
+	other, err := newExperimentHistory(filepath.Dir(s.history.basename))
+	c.Assert(err, IsNil)
+	last, err := other.Last()
+	c.Assert(err, IsNil)
+	c.Check(last.ExperimentDir, Equals, "foo.0000")
+}
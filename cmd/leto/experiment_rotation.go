@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+)
+
+// An experimentRotation periodically rolls the output of a running
+// experiment into a new numbered experiment directory, on a fixed
+// wall-clock schedule. Subscribers are notified of each new
+// directory, and artemis new ant snapshots are redirected through a
+// symbolic link, so artemis does not need to be restarted.
+type experimentRotation struct {
+	mx          sync.Mutex
+	dirs        []string
+	subscribers []chan string
+
+	basename string
+	antLink  string
+	offset   time.Duration
+	period   time.Duration
+	// saveConfig saves the configuration and its provenance in a
+	// new experiment directory.
+	saveConfig func(dir string) error
+
+	logger *logrus.Entry
+}
+
+// parseRotationTime parses an HH:MM wall-clock time and returns its
+// offset from midnight.
+func parseRotationTime(at string) (time.Duration, error) {
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rotation time '%s': expected HH:MM", at)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func newExperimentRotation(basename, firstDir string, config *leto.TrackingConfiguration, saveConfig func(dir string) error) (*experimentRotation, error) {
+	offset, err := parseRotationTime(*config.Rotation.At)
+	if err != nil {
+		return nil, err
+	}
+	if *config.Rotation.Period < time.Minute {
+		return nil, fmt.Errorf("rotation period (%s) should be at least 1m", *config.Rotation.Period)
+	}
+
+	return &experimentRotation{
+		dirs:       []string{firstDir},
+		basename:   basename,
+		antLink:    filepath.Join(filepath.Dir(firstDir), "."+filepath.Base(firstDir)+".ants"),
+		offset:     offset,
+		period:     *config.Rotation.Period,
+		saveConfig: saveConfig,
+		logger:     tm.NewLogger("rotation").WithField("experiment", config.ExperimentName),
+	}, nil
+}
+
+// nextRotation returns the first rotation time strictly after now.
+// Rotations are computed in wall-clock time from the rotation time of
+// the day of now, so they keep their time of day across DST changes.
+func (r *experimentRotation) nextRotation(now time.Time) time.Time {
+	y, m, d := now.Date()
+	hour, minute := int(r.offset/time.Hour), int(r.offset%time.Hour/time.Minute)
+	rotation := func(i int) time.Time {
+		// time.Date normalizes the nanoseconds in wall-clock time.
+		return time.Date(y, m, d, hour, minute, 0, int(time.Duration(i)*r.period), now.Location())
+	}
+	i := 0
+	for rotation(i).After(now) == true {
+		i--
+	}
+	for rotation(i).After(now) == false {
+		i++
+	}
+	return rotation(i)
+}
+
+// Current returns the experiment directory currently in use.
+func (r *experimentRotation) Current() string {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.dirs[len(r.dirs)-1]
+}
+
+// Directories returns all directories used by the experiment so far.
+func (r *experimentRotation) Directories() []string {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return append([]string(nil), r.dirs...)
+}
+
+// Subscribe returns a channel that receives each new experiment
+// directory. It must be called before Run().
+func (r *experimentRotation) Subscribe() <-chan string {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	res := make(chan string, 1)
+	r.subscribers = append(r.subscribers, res)
+	return res
+}
+
+// linkAnts points the artemis new ant output directory to the ants
+// directory of dir.
+func (r *experimentRotation) linkAnts(dir string) error {
+	tmpLink := r.antLink + ".new"
+	os.Remove(tmpLink)
+	if err := os.Symlink(filepath.Join(dir, "ants"), tmpLink); err != nil {
+		return err
+	}
+	return os.Rename(tmpLink, r.antLink)
+}
+
+func (r *experimentRotation) Run(ctx context.Context) error {
+	for {
+		next := r.nextRotation(time.Now())
+		r.logger.WithField("at", next).Debug("next rotation")
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		if err := r.rotate(); err != nil {
+			r.logger.WithError(err).Error("could not rotate experiment directory")
+		}
+	}
+}
+
+func (r *experimentRotation) rotate() error {
+	previous := r.Current()
+	next, _, err := FilenameWithoutOverwrite(r.basename)
+	if err != nil {
+		return fmt.Errorf("could not find unique directory name: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(next, "ants"), 0755); err != nil {
+		return fmt.Errorf("could not create %s: %w", next, err)
+	}
+
+	if err := r.saveConfig(next); err != nil {
+		return err
+	}
+
+	if err := os.Symlink(filepath.Join("..", filepath.Base(previous)), filepath.Join(next, "previous")); err != nil {
+		return err
+	}
+	if err := os.Symlink(filepath.Join("..", filepath.Base(next)), filepath.Join(previous, "next")); err != nil {
+		return err
+	}
+
+	if err := r.linkAnts(next); err != nil {
+		return fmt.Errorf("could not redirect new ant output: %w", err)
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	r.dirs = append(r.dirs, next)
+	for _, s := range r.subscribers {
+		// a subscriber that did not process the last rotation yet
+		// should only care about the latest one.
+		select {
+		case <-s:
+		default:
+		}
+		s <- next
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"previous": filepath.Base(previous),
+		"next":     filepath.Base(next),
+	}).Info("rotated experiment directory")
+
+	return nil
+}
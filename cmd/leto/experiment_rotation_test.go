@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	. "gopkg.in/check.v1"
+)
+
+type ExperimentRotationSuite struct {
+	basedir string
+	config  leto.TrackingConfiguration
+}
+
+var _ = Suite(&ExperimentRotationSuite{})
+
+func (s *ExperimentRotationSuite) SetUpTest(c *C) {
+	s.basedir = c.MkDir()
+	s.config = leto.RecommendedTrackingConfiguration()
+	s.config.ExperimentName = "rotated"
+	*s.config.Rotation.At = "00:00"
+}
+
+func (s *ExperimentRotationSuite) TestParseRotationTime(c *C) {
+	offset, err := parseRotationTime("13:37")
+	c.Check(err, IsNil)
+	c.Check(offset, Equals, 13*time.Hour+37*time.Minute)
+
+	_, err = parseRotationTime("midnight")
+	c.Check(err, ErrorMatches, "invalid rotation time 'midnight': expected HH:MM")
+}
+
+func (s *ExperimentRotationSuite) TestNextRotation(c *C) {
+	testdata := []struct {
+		At       string
+		Period   time.Duration
+		Now      time.Time
+		Expected time.Time
+	}{
+		{
+			At:       "00:00",
+			Period:   24 * time.Hour,
+			Now:      time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC),
+			Expected: time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			At:       "00:00",
+			Period:   24 * time.Hour,
+			Now:      time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC),
+			Expected: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			At:       "18:30",
+			Period:   24 * time.Hour,
+			Now:      time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC),
+			Expected: time.Date(2023, 4, 1, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			At:       "18:00",
+			Period:   6 * time.Hour,
+			Now:      time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC),
+			Expected: time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for i, d := range testdata {
+		*s.config.Rotation.At = d.At
+		*s.config.Rotation.Period = d.Period
+		r, err := newExperimentRotation(filepath.Join(s.basedir, "rotated"),
+			filepath.Join(s.basedir, "rotated.0000"), &s.config, nil)
+		c.Assert(err, IsNil)
+		c.Check(r.nextRotation(d.Now), Equals, d.Expected, Commentf("testdata %d", i))
+	}
+}
+
+func (s *ExperimentRotationSuite) TestNextRotationAcrossDST(c *C) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Assert(err, IsNil)
+	testdata := []struct {
+		Period   time.Duration
+		Now      time.Time
+		Expected time.Time
+	}{
+		// clocks go forward on 2023-03-26 at 02:00.
+		{24 * time.Hour, time.Date(2023, 3, 25, 10, 0, 0, 0, berlin), time.Date(2023, 3, 26, 6, 0, 0, 0, berlin)},
+		{24 * time.Hour, time.Date(2023, 3, 26, 1, 0, 0, 0, berlin), time.Date(2023, 3, 26, 6, 0, 0, 0, berlin)},
+		{6 * time.Hour, time.Date(2023, 3, 26, 7, 0, 0, 0, berlin), time.Date(2023, 3, 26, 12, 0, 0, 0, berlin)},
+		// clocks go back on 2023-10-29 at 03:00.
+		{24 * time.Hour, time.Date(2023, 10, 28, 10, 0, 0, 0, berlin), time.Date(2023, 10, 29, 6, 0, 0, 0, berlin)},
+		{24 * time.Hour, time.Date(2023, 10, 29, 1, 0, 0, 0, berlin), time.Date(2023, 10, 29, 6, 0, 0, 0, berlin)},
+		{6 * time.Hour, time.Date(2023, 10, 29, 7, 0, 0, 0, berlin), time.Date(2023, 10, 29, 12, 0, 0, 0, berlin)},
+	}
+
+	*s.config.Rotation.At = "06:00"
+	for i, d := range testdata {
+		*s.config.Rotation.Period = d.Period
+		r, err := newExperimentRotation(filepath.Join(s.basedir, "rotated"),
+			filepath.Join(s.basedir, "rotated.0000"), &s.config, nil)
+		c.Assert(err, IsNil)
+		next := r.nextRotation(d.Now)
+		c.Check(next.Equal(d.Expected), Equals, true, Commentf("testdata %d: got %s", i, next))
+	}
+}
+
+func (s *ExperimentRotationSuite) TestRotate(c *C) {
+	first := filepath.Join(s.basedir, "rotated.0000")
+	c.Assert(os.MkdirAll(filepath.Join(first, "ants"), 0755), IsNil)
+
+	e := &TrackingEnvironment{
+		Config:     &s.config,
+		Provenance: leto.ConfigurationProvenance{"experiment": leto.UpdateLayer},
+	}
+	r, err := newExperimentRotation(filepath.Join(s.basedir, "rotated"), first, &s.config, e.saveLocalConfig)
+	c.Assert(err, IsNil)
+	c.Assert(r.linkAnts(first), IsNil)
+	rotations := r.Subscribe()
+
+	c.Assert(r.rotate(), IsNil)
+	second := filepath.Join(s.basedir, "rotated.0001")
+
+	select {
+	case dir := <-rotations:
+		c.Check(dir, Equals, second)
+	default:
+		c.Errorf("subscriber was not notified")
+	}
+	c.Check(r.Current(), Equals, second)
+	c.Check(r.Directories(), DeepEquals, []string{first, second})
+
+	for _, name := range []string{"leto-final-config.yaml", "leto-config-provenance.yaml"} {
+		_, err = os.Stat(filepath.Join(second, name))
+		c.Check(err, IsNil, Commentf("%s", name))
+	}
+
+	target, err := os.Readlink(filepath.Join(second, "previous"))
+	c.Check(err, IsNil)
+	c.Check(target, Equals, "../rotated.0000")
+	target, err = os.Readlink(filepath.Join(first, "next"))
+	c.Check(err, IsNil)
+	c.Check(target, Equals, "../rotated.0001")
+	target, err = os.Readlink(r.antLink)
+	c.Check(err, IsNil)
+	c.Check(target, Equals, filepath.Join(second, "ants"))
+}
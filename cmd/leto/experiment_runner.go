@@ -1,10 +1,12 @@
 package main
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"time"
 
+	"github.com/formicidae-tracker/leto/internal/leto"
 	"github.com/formicidae-tracker/leto/pkg/letopb"
 	"github.com/formicidae-tracker/olympus/pkg/tm"
 	"github.com/sirupsen/logrus"
@@ -12,6 +14,27 @@ import (
 
 type ExperimentRunner interface {
 	Run() (*letopb.ExperimentLog, error)
+	// Started returns a channel receiving a report once the
+	// experiment is started on all nodes, or could not be started.
+	Started() <-chan startReport
+	// Reconfigure applies the changes of the running experiment
+	// configuration, now config, to the affected subtasks.
+	Reconfigure(config *leto.TrackingConfiguration, changes []leto.ConfigurationChange) error
+	// Pause stops the experiment like a stop, but Run then returns
+	// errExperimentPaused and keeps the environment, for a new runner
+	// to resume the experiment.
+	Pause()
+}
+
+// errExperimentPaused is returned by ExperimentRunner.Run once the
+// experiment was paused without errors.
+var errExperimentPaused = errors.New("experiment paused")
+
+// A startReport is the result of starting an experiment on the
+// slaves of a node.
+type startReport struct {
+	Slaves []*letopb.NodeResult
+	Err    error
 }
 
 type slaveRunner struct {
@@ -20,6 +43,25 @@ type slaveRunner struct {
 	logger     *logrus.Entry
 }
 
+// Started returns a report without slaves, a slave node has no slaves
+// to start.
+func (r *slaveRunner) Started() <-chan startReport {
+	res := make(chan startReport, 1)
+	res <- startReport{}
+	return res
+}
+
+// Reconfigure does nothing, the live fields are only used by the
+// master.
+func (r *slaveRunner) Reconfigure(config *leto.TrackingConfiguration, changes []leto.ConfigurationChange) error {
+	return nil
+}
+
+// Pause does nothing, slaves are stopped by their master when it
+// pauses.
+func (r *slaveRunner) Pause() {
+}
+
 func NewExperimentRunner(env *TrackingEnvironment) (ExperimentRunner, error) {
 	if env.Node.IsMaster() == true {
 		return newMasterRunner(env)
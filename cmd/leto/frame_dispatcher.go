@@ -1,26 +1,59 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"path"
+	"sync/atomic"
 
 	"github.com/formicidae-tracker/hermes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type FrameDispatcher interface {
 	Task
 	Incoming() chan<- *hermes.FrameReadout
+	// Dropped returns the number of frames dropped for each output.
+	Dropped() map[string]int64
+}
+
+// A DispatchOutput is a destination of a FrameDispatcher.
+type DispatchOutput struct {
+	Name    string
+	Channel chan<- *hermes.FrameReadout
+	// Lossless outputs never drop frames: the dispatcher waits until
+	// they accept them, so they must read Channel until it is closed,
+	// even after a failure. Other outputs drop frames when they are
+	// not ready.
+	Lossless bool
+	// Discarded, if not nil, counts the frames an output accepted
+	// but discarded after a failure. They are reported as dropped.
+	Discarded *atomic.Int64
 }
 
 type frameDispatcher struct {
 	incoming chan *hermes.FrameReadout
-	outgoing []chan<- *hermes.FrameReadout
+	outputs  []DispatchOutput
+	dropped  []atomic.Int64
 }
 
-func NewFrameDispatcher(outgoing ...chan<- *hermes.FrameReadout) FrameDispatcher {
-	return &frameDispatcher{
+// NewFrameDispatcher creates a FrameDispatcher sending each incoming
+// frame to all outputs.
+func NewFrameDispatcher(outputs ...DispatchOutput) FrameDispatcher {
+	res := &frameDispatcher{
 		incoming: make(chan *hermes.FrameReadout, 10),
-		outgoing: outgoing,
+		outputs:  outputs,
+		dropped:  make([]atomic.Int64, len(outputs)),
 	}
+
+	otel.Meter(instrumentationName).
+		Int64ObservableCounter(path.Join("leto", "frameDispatchDropped"),
+			metric.WithInt64Callback(res.reportDropped),
+		)
+
+	return res
 }
 
 func (d *frameDispatcher) Run() (err error) {
@@ -32,22 +65,63 @@ func (d *frameDispatcher) Run() (err error) {
 	}()
 	defer d.closeOutgoing()
 	for r := range d.incoming {
-		for _, o := range d.outgoing {
-			select {
-			case o <- r:
-			default:
+		for i, o := range d.outputs {
+			if d.send(o, r) == false {
+				d.dropped[i].Add(1)
 			}
 		}
 	}
 	return nil
 }
 
+// send returns false if the frame was dropped, which never happens
+// for lossless outputs.
+func (d *frameDispatcher) send(o DispatchOutput, r *hermes.FrameReadout) bool {
+	select {
+	case o.Channel <- r:
+		return true
+	default:
+	}
+
+	if o.Lossless == false {
+		return false
+	}
+
+	o.Channel <- r
+	return true
+}
+
 func (d *frameDispatcher) Incoming() chan<- *hermes.FrameReadout {
 	return d.incoming
 }
 
+func (d *frameDispatcher) Dropped() map[string]int64 {
+	res := make(map[string]int64, len(d.outputs))
+	for i, o := range d.outputs {
+		res[o.Name] = d.droppedBy(i)
+	}
+	return res
+}
+
+// droppedBy returns the frames dropped by the dispatcher or
+// discarded by the i-th output.
+func (d *frameDispatcher) droppedBy(i int) int64 {
+	res := d.dropped[i].Load()
+	if d.outputs[i].Discarded != nil {
+		res += d.outputs[i].Discarded.Load()
+	}
+	return res
+}
+
+func (d *frameDispatcher) reportDropped(_ context.Context, obs metric.Int64Observer) error {
+	for i, o := range d.outputs {
+		obs.Observe(d.droppedBy(i), metric.WithAttributes(attribute.String("output", o.Name)))
+	}
+	return nil
+}
+
 func (d *frameDispatcher) closeOutgoing() {
-	for _, o := range d.outgoing {
-		close(o)
+	for _, o := range d.outputs {
+		close(o.Channel)
 	}
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/formicidae-tracker/hermes"
@@ -11,6 +12,7 @@ import (
 type FrameDispatcherSuite struct {
 	dispatcher FrameDispatcher
 	out1, out2 chan *hermes.FrameReadout
+	discarded  atomic.Int64
 	err        <-chan error
 }
 
@@ -19,7 +21,11 @@ var _ = Suite(&FrameDispatcherSuite{})
 func (s *FrameDispatcherSuite) SetUpTest(c *C) {
 	s.out1 = make(chan *hermes.FrameReadout, 1)
 	s.out2 = make(chan *hermes.FrameReadout, 1)
-	s.dispatcher = NewFrameDispatcher(s.out1, s.out2)
+	s.discarded.Store(0)
+	s.dispatcher = NewFrameDispatcher(
+		DispatchOutput{Name: "out1", Channel: s.out1, Lossless: true, Discarded: &s.discarded},
+		DispatchOutput{Name: "out2", Channel: s.out2},
+	)
 	s.err = Start(s.dispatcher)
 }
 
@@ -81,7 +87,50 @@ func (s *FrameDispatcherSuite) TestE2E(c *C) {
 
 	c.Check(res1, DeepEquals, []int64{1, 2, 3})
 	c.Check(res2, DeepEquals, []int64{1, 2})
+	c.Check(s.dispatcher.Dropped(), DeepEquals, map[string]int64{"out1": 0, "out2": 1})
+}
+
+func (s *FrameDispatcherSuite) TestLosslessOutputWaits(c *C) {
+	go func() {
+		for range s.out2 {
+		}
+	}()
+	for i := int64(1); i <= 3; i++ {
+		s.dispatcher.Incoming() <- &hermes.FrameReadout{FrameID: i}
+	}
+	close(s.dispatcher.Incoming())
+
+	// lets the dispatcher block on out1.
+	time.Sleep(10 * time.Millisecond)
+	var res []int64
+	for r := range s.out1 {
+		res = append(res, r.FrameID)
+	}
+	c.Check(res, DeepEquals, []int64{1, 2, 3})
+	c.Check(s.dispatcher.Dropped()["out1"], Equals, int64(0))
+}
+
+func (s *FrameDispatcherSuite) TestDiscardedFramesAreDropped(c *C) {
+	go func() {
+		for range s.out2 {
+		}
+	}()
+	// a failed lossless output discards the frames it accepts.
+	go func() {
+		for range s.out1 {
+			s.discarded.Add(1)
+		}
+	}()
+	for i := int64(1); i <= 3; i++ {
+		s.dispatcher.Incoming() <- &hermes.FrameReadout{FrameID: i}
+	}
+	close(s.dispatcher.Incoming())
+	c.Check(<-s.err, IsNil)
 
+	for i := 0; i < 100 && s.discarded.Load() < 3; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	c.Check(s.dispatcher.Dropped()["out1"], Equals, int64(3))
 }
 
 func (s *FrameDispatcherSuite) TestHandleChannelPanics(c *C) {
@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"math"
 	"path"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
@@ -25,21 +28,50 @@ func (p synchronizationPoint) computeOffset(t time.Time, timestampUs int64) floa
 	return float64(p.timestampUS) + float64(t.Sub(p.time).Nanoseconds())*1.0e-3 - float64(timestampUs)
 }
 
+// A ProducerSynchronization is the clock synchronization state of a
+// slave producer with the master. Values are in microseconds.
+type ProducerSynchronization struct {
+	Producer string
+	Offset   float64
+	// Drift is the offset change since the first frame of the
+	// producer.
+	Drift  float64
+	Jitter float64
+}
+
 type WorkloadBalance struct {
 	FPS        float64
 	Stride     int
 	MasterUUID string
-	lastPoint  *synchronizationPoint
-	offsets    map[string]float64
-	IDsByUUID  map[string][]bool
+	// Hostnames are the names of the producers, by UUID, used in
+	// diagnostics.
+	Hostnames map[string]string
+
+	// mx protects the synchronization state, read by Synchronization
+	// while frames are checked, and IDsByUUID once reassigned.
+	mx             sync.Mutex
+	lastPoint      *synchronizationPoint
+	offsets        map[string]float64
+	initialOffsets map[string]float64
+	jitters        map[string]float64
+
+	IDsByUUID map[string][]bool
+	// DeadAfter is the number of consecutive timeouts, per frame
+	// produced in a stride, after which a slave producer is
+	// considered dead. Zero disables the detection.
+	DeadAfter int
 }
 
 func (wb *WorkloadBalance) Check() error {
 	if len(wb.MasterUUID) == 0 {
 		return fmt.Errorf("Work Balance is missing master UUID")
 	}
+	wb.mx.Lock()
 	wb.offsets = make(map[string]float64)
+	wb.initialOffsets = make(map[string]float64)
+	wb.jitters = make(map[string]float64)
 	wb.lastPoint = nil
+	wb.mx.Unlock()
 	fids := map[int]string{}
 
 	if len(wb.IDsByUUID) > wb.Stride {
@@ -74,6 +106,71 @@ func (wb *WorkloadBalance) FrameID(ID int64) int {
 	return int(ID % int64(wb.Stride))
 }
 
+// ProducerOf returns the UUID of the producer of frames fid mod
+// [Stride].
+func (wb *WorkloadBalance) ProducerOf(fid int) string {
+	for puuid, ids := range wb.IDsByUUID {
+		if ids[fid] == true {
+			return puuid
+		}
+	}
+	return ""
+}
+
+// framesPerStride returns the number of frames produced by puuid in
+// a stride.
+func (wb *WorkloadBalance) framesPerStride(puuid string) int {
+	res := 0
+	for _, set := range wb.IDsByUUID[puuid] {
+		if set == true {
+			res += 1
+		}
+	}
+	return res
+}
+
+// reassign sets new IDs for all producers, dropping the state of
+// producers that are not present anymore.
+func (wb *WorkloadBalance) reassign(IDsByUUID map[string][]bool) {
+	wb.mx.Lock()
+	defer wb.mx.Unlock()
+	wb.IDsByUUID = IDsByUUID
+	for puuid := range wb.offsets {
+		if _, ok := IDsByUUID[puuid]; ok == false {
+			delete(wb.offsets, puuid)
+			delete(wb.initialOffsets, puuid)
+			delete(wb.jitters, puuid)
+		}
+	}
+}
+
+// Synchronization returns the time of the last master frame, and the
+// clock synchronization of all slave producers which sent a frame,
+// sorted by name. The time is zero if no master frame was received.
+func (wb *WorkloadBalance) Synchronization() (time.Time, []ProducerSynchronization) {
+	wb.mx.Lock()
+	defer wb.mx.Unlock()
+	var lastSync time.Time
+	if wb.lastPoint != nil {
+		lastSync = wb.lastPoint.time
+	}
+	res := make([]ProducerSynchronization, 0, len(wb.offsets))
+	for puuid, offset := range wb.offsets {
+		name, ok := wb.Hostnames[puuid]
+		if ok == false {
+			name = puuid
+		}
+		res = append(res, ProducerSynchronization{
+			Producer: name,
+			Offset:   offset,
+			Drift:    offset - wb.initialOffsets[puuid],
+			Jitter:   wb.jitters[puuid],
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Producer < res[j].Producer })
+	return lastSync, res
+}
+
 func (wb *WorkloadBalance) CheckFrame(f *hermes.FrameReadout) (int, error) {
 	if len(f.ProducerUuid) == 0 {
 		return -1, fmt.Errorf("Received frame has no ProducerUUID")
@@ -96,6 +193,8 @@ func (wb *WorkloadBalance) CheckFrame(f *hermes.FrameReadout) (int, error) {
 		return -1, err
 	}
 
+	wb.mx.Lock()
+	defer wb.mx.Unlock()
 	if f.ProducerUuid == wb.MasterUUID {
 		if wb.lastPoint == nil {
 			wb.lastPoint = &synchronizationPoint{}
@@ -110,7 +209,9 @@ func (wb *WorkloadBalance) CheckFrame(f *hermes.FrameReadout) (int, error) {
 		offset, ok := wb.offsets[f.ProducerUuid]
 		if ok == false {
 			offset = currentOffset
+			wb.initialOffsets[f.ProducerUuid] = offset
 		} else {
+			wb.jitters[f.ProducerUuid] += 0.2 * (math.Abs(currentOffset-offset) - wb.jitters[f.ProducerUuid])
 			offset += 0.2 * (currentOffset - offset)
 		}
 		wb.offsets[f.ProducerUuid] = offset
@@ -119,6 +220,41 @@ func (wb *WorkloadBalance) CheckFrame(f *hermes.FrameReadout) (int, error) {
 	return fid, nil
 }
 
+// reportSynchronization exports the clock synchronization of all
+// slave producers as metrics.
+func (wb *WorkloadBalance) reportSynchronization(meter metric.Meter) {
+	values := map[string]func(ProducerSynchronization) float64{
+		"clockOffset": func(p ProducerSynchronization) float64 { return p.Offset },
+		"clockDrift":  func(p ProducerSynchronization) float64 { return p.Drift },
+		"clockJitter": func(p ProducerSynchronization) float64 { return p.Jitter },
+	}
+	for name := range values {
+		// note: we should capture the right function
+		value := values[name]
+		meter.Float64ObservableGauge(path.Join("leto", name),
+			metric.WithUnit("us"),
+			metric.WithFloat64Callback(func(_ context.Context, obs metric.Float64Observer) error {
+				_, producers := wb.Synchronization()
+				for _, p := range producers {
+					obs.Observe(value(p), metric.WithAttributes(attribute.String("producer", p.Producer)))
+				}
+				return nil
+			}),
+		)
+	}
+
+	meter.Float64ObservableGauge(path.Join("leto", "timeSinceMasterSync"),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, obs metric.Float64Observer) error {
+			lastSync, _ := wb.Synchronization()
+			if lastSync.IsZero() == false {
+				obs.Observe(time.Since(lastSync).Seconds())
+			}
+			return nil
+		}),
+	)
+}
+
 type ReadoutBuffer []*hermes.FrameReadout
 
 func (r ReadoutBuffer) Len() int {
@@ -142,7 +278,12 @@ func BuildAtomicInt64Callback(v *atomic.Int64) metric.Int64Callback {
 	}
 }
 
-func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan *hermes.FrameReadout, outbound chan<- *hermes.FrameReadout) error {
+// MergeFrameReadout merges the frames of all producers of wb from
+// inbound to outbound, in order, marking the missing ones as
+// timeouted. If rebalancer is not nil, slave producers timeouting
+// for too long are reported to it, and its new assignations are
+// applied.
+func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan *hermes.FrameReadout, outbound chan<- *hermes.FrameReadout, rebalancer *workloadRebalancer) error {
 	defer close(outbound)
 	logger := tm.NewLogger("frame-merger").WithContext(ctx)
 
@@ -165,6 +306,9 @@ func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan
 	if err := wb.Check(); err != nil {
 		return err
 	}
+	if wb.Stride > 1 {
+		wb.reportSynchronization(meter)
+	}
 
 	nextFrameToSend := int64(0)
 	maxFrame := int64(-1)
@@ -174,6 +318,12 @@ func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan
 	betweenFrame := time.Duration(1.0e9/wb.FPS) * time.Nanosecond
 	timeout := time.Duration(2*wb.Stride+2) * betweenFrame
 
+	var reassignations <-chan map[string][]bool
+	if rebalancer != nil {
+		reassignations = rebalancer.Reassignations()
+	}
+	consecutiveTimeouts := map[string]int{}
+
 	for {
 		var timer *time.Timer = nil
 		var timeoutC <-chan time.Time = nil
@@ -198,6 +348,7 @@ func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan
 				logger.WithError(err).Error("workbalance error")
 				continue
 			}
+			consecutiveTimeouts[frame.ProducerUuid] = 0
 			now = time.Now()
 			if len(deadlines) == 0 {
 				nextFrameToSend = frame.FrameID
@@ -219,6 +370,11 @@ func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan
 
 		case t := <-timeoutC:
 			now = t
+		case IDsByUUID := <-reassignations:
+			logger.WithField("IDs", IDsByUUID).Info("new workload assignation")
+			wb.reassign(IDsByUUID)
+			consecutiveTimeouts = map[string]int{}
+			now = time.Now()
 		}
 		if timer != nil {
 			timer.Stop()
@@ -243,6 +399,17 @@ func MergeFrameReadout(ctx context.Context, wb *WorkloadBalance, inbound <-chan
 				buffer = append(buffer, ro)
 				delete(deadlines, i)
 				deadlines[i+int64(wb.Stride)] = now.Add(timeout)
+
+				producer := wb.ProducerOf(wb.FrameID(i))
+				consecutiveTimeouts[producer] += 1
+				if rebalancer != nil && wb.DeadAfter > 0 && producer != wb.MasterUUID &&
+					consecutiveTimeouts[producer] == wb.DeadAfter*wb.framesPerStride(producer) {
+					logger.WithFields(logrus.Fields{
+						"producer": producer,
+						"timeouts": consecutiveTimeouts[producer],
+					}).Error("producer seems dead")
+					rebalancer.ReportDead(producer)
+				}
 			}
 		}
 		//we sort them all
@@ -91,7 +91,7 @@ func (s *FrameReadoutMergerSuite) TestEnd2End(c *C) {
 	}
 
 	go func() {
-		err := MergeFrameReadout(context.TODO(), wb, inbound, outbound)
+		err := MergeFrameReadout(context.TODO(), wb, inbound, outbound, nil)
 		c.Check(err, IsNil)
 		if err != nil {
 			for range inbound {
@@ -122,3 +122,86 @@ func (s *FrameReadoutMergerSuite) TestEnd2End(c *C) {
 	wg.Wait()
 
 }
+
+func (s *FrameReadoutMergerSuite) TestReportsDeadProducers(c *C) {
+	fps := 100.0
+	period := time.Duration(float64(time.Second.Nanoseconds()) / fps)
+	wb := &WorkloadBalance{
+		FPS:        fps,
+		Stride:     2,
+		MasterUUID: "foo",
+		IDsByUUID: map[string][]bool{
+			"foo": {true, false},
+			"bar": {false, true},
+		},
+		DeadAfter: 3,
+	}
+	rebalancer := &workloadRebalancer{
+		dead:           make(chan string, 1),
+		reassignations: make(chan map[string][]bool),
+	}
+
+	inbound := make(chan *hermes.FrameReadout)
+	outbound := make(chan *hermes.FrameReadout, 100)
+	reassigned := make(chan struct{})
+	stop := make(chan struct{})
+	go func() {
+		defer close(inbound)
+		start := time.Now()
+		isReassigned := false
+		for ID := int64(0); ; ID++ {
+			select {
+			case <-stop:
+				return
+			case <-reassigned:
+				isReassigned = true
+			default:
+			}
+			producerUuid := "foo"
+			if ID%2 == 1 {
+				// bar only produces its first frame.
+				if isReassigned == false && ID != 1 {
+					continue
+				}
+				if ID == 1 {
+					producerUuid = "bar"
+				}
+			}
+			deadline := start.Add(time.Duration(ID) * period)
+			time.Sleep(time.Until(deadline))
+			frame := &hermes.FrameReadout{FrameID: ID, ProducerUuid: producerUuid}
+			frame.Time, _ = ptypes.TimestampProto(deadline)
+			inbound <- frame
+		}
+	}()
+
+	errs := make(chan error)
+	go func() {
+		errs <- MergeFrameReadout(context.Background(), wb, inbound, outbound, rebalancer)
+	}()
+
+	timeout := time.After(5 * time.Second)
+	last := int64(-1)
+	tracked := 0
+	for tracked < 3 {
+		select {
+		case dead := <-rebalancer.dead:
+			c.Check(dead, Equals, "bar")
+			rebalancer.reassignations <- map[string][]bool{"foo": {true, true}}
+			close(reassigned)
+		case ro := <-outbound:
+			c.Check(ro.FrameID, Equals, last+1)
+			last = ro.FrameID
+			// odd frames are now tracked by foo.
+			if ro.FrameID > 1 && ro.FrameID%2 == 1 && ro.Error == hermes.FrameReadout_NO_ERROR {
+				tracked += 1
+			}
+		case <-timeout:
+			c.Fatalf("frames were not reassigned, last frame: %d", last)
+		}
+	}
+	close(stop)
+	for range outbound {
+	}
+	c.Check(<-errs, IsNil)
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// A FrameSink is an additional consumer of the frame readouts of an
+// experiment, configured in the sinks section of the
+// configuration. It runs until incoming is closed.
+type FrameSink interface {
+	Run(incoming <-chan *hermes.FrameReadout) error
+}
+
+// A frameSinkType creates the FrameSink of a given type.
+type frameSinkType struct {
+	// Lossless sinks never miss a frame, but could slow down the
+	// whole tracking if they are too slow.
+	lossless bool
+	check    func(config leto.SinkConfiguration) error
+	create   func(ctx context.Context, env *TrackingEnvironment, boundaries *segmentBoundaries, config leto.SinkConfiguration) (FrameSink, error)
+}
+
+// frameSinkTypes lists all available sink types. New types should be
+// added here.
+var frameSinkTypes = map[string]frameSinkType{
+	"csv": {
+		lossless: true,
+		check:    requireSinkPath,
+		create:   newCSVSink,
+	},
+	"udp": {
+		check:  requireSinkAddress,
+		create: newUDPSink,
+	},
+	"unix": {
+		check:  requireSinkPath,
+		create: newUnixSink,
+	},
+}
+
+func requireSinkPath(config leto.SinkConfiguration) error {
+	if len(config.Path) == 0 {
+		return fmt.Errorf("%s sink requires a path", config.Type)
+	}
+	return nil
+}
+
+func requireSinkAddress(config leto.SinkConfiguration) error {
+	if len(config.Address) == 0 {
+		return fmt.Errorf("%s sink requires an address", config.Type)
+	}
+	return nil
+}
+
+func sinkTypeNames() string {
+	names := make([]string, 0, len(frameSinkTypes))
+	for n := range frameSinkTypes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// sinkName returns the name of the idx-th sink, used in logs and
+// metrics.
+func sinkName(idx int, config leto.SinkConfiguration) string {
+	if len(config.Name) > 0 {
+		return config.Name
+	}
+	return fmt.Sprintf("%s-%d", config.Type, idx)
+}
+
+// checkSinks returns an error if any sink in sinks is invalid.
+func checkSinks(sinks []leto.SinkConfiguration) error {
+	names := make(map[string]bool)
+	for i, config := range sinks {
+		t, ok := frameSinkTypes[config.Type]
+		if ok == false {
+			return fmt.Errorf("sink %d: unknown type '%s': available types are %s", i, config.Type, sinkTypeNames())
+		}
+		if err := t.check(config); err != nil {
+			return fmt.Errorf("sink %d: %w", i, err)
+		}
+		name := sinkName(i, config)
+		if names[name] == true {
+			return fmt.Errorf("sink %d: duplicated name '%s'", i, name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// A configuredSink is a FrameSink instantiated from the
+// configuration, with its dispatcher input.
+type configuredSink struct {
+	name      string
+	lossless  bool
+	sink      FrameSink
+	incoming  chan *hermes.FrameReadout
+	discarded atomic.Int64
+}
+
+func (s *configuredSink) DispatchOutput() DispatchOutput {
+	return DispatchOutput{
+		Name:      s.name,
+		Channel:   s.incoming,
+		Lossless:  s.lossless,
+		Discarded: &s.discarded,
+	}
+}
+
+// Run runs the sink. If it fails early, the remaining frames are
+// discarded, and counted as dropped, so the dispatcher is never
+// blocked by a failed sink.
+func (s *configuredSink) Run() error {
+	err := s.sink.Run(s.incoming)
+	for range s.incoming {
+		s.discarded.Add(1)
+	}
+	return err
+}
+
+// NewFrameSinks creates all sinks of the experiment configuration. Sinks
+// writing in the experiment directory follow its rotations on the
+// segment boundaries decided by boundaries, which could be nil.
+func NewFrameSinks(ctx context.Context, env *TrackingEnvironment, boundaries *segmentBoundaries) ([]*configuredSink, error) {
+	if env.Config.Sinks == nil {
+		return nil, nil
+	}
+	res := make([]*configuredSink, 0, len(*env.Config.Sinks))
+	for i, config := range *env.Config.Sinks {
+		t, ok := frameSinkTypes[config.Type]
+		if ok == false {
+			return nil, fmt.Errorf("unknown sink type '%s'", config.Type)
+		}
+		sink, err := t.create(ctx, env, boundaries, config)
+		if err != nil {
+			return nil, fmt.Errorf("could not create sink %s: %w", sinkName(i, config), err)
+		}
+		res = append(res, &configuredSink{
+			name:     sinkName(i, config),
+			lossless: t.lossless,
+			sink:     sink,
+			incoming: make(chan *hermes.FrameReadout, 200),
+		})
+	}
+	return res, nil
+}
+
+// A csvSink writes the position of every detected tag in a CSV
+// file. A relative path is relative to the current experiment
+// directory: on each rotation, a new file is started in the new
+// directory. An absolute path is used as is, and never changes.
+type csvSink struct {
+	path     string
+	filename string
+	pending  segmentBoundaryQueue
+
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func newCSVSink(ctx context.Context, env *TrackingEnvironment, boundaries *segmentBoundaries, config leto.SinkConfiguration) (FrameSink, error) {
+	if filepath.IsAbs(config.Path) == true {
+		return &csvSink{path: config.Path, filename: config.Path}, nil
+	}
+	return &csvSink{
+		path:     config.Path,
+		filename: filepath.Join(env.CurrentExperimentDir(), config.Path),
+		pending:  newSegmentBoundaryQueue(boundaries),
+	}, nil
+}
+
+// open appends to the file, so a resumed experiment keeps the lines
+// written before its pause. The header is only written in a new file.
+func (s *csvSink) open() error {
+	f, err := os.OpenFile(s.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.writer = bufio.NewWriter(f)
+	info, err := f.Stat()
+	if err != nil || info.Size() > 0 {
+		return err
+	}
+	_, err = fmt.Fprintln(s.writer, "frame_id,time,tag_id,x,y,angle")
+	return err
+}
+
+func (s *csvSink) close() error {
+	if s.file == nil {
+		return nil
+	}
+	defer func() {
+		s.file = nil
+		s.writer = nil
+	}()
+	err := s.writer.Flush()
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (s *csvSink) Run(incoming <-chan *hermes.FrameReadout) (retError error) {
+	defer func() {
+		if err := s.close(); err != nil && retError == nil {
+			retError = err
+		}
+	}()
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	for r := range incoming {
+		if _, dir := s.pending.Reached(r.FrameID); len(dir) > 0 {
+			if err := s.close(); err != nil {
+				return err
+			}
+			s.filename = filepath.Join(dir, s.path)
+			if err := s.open(); err != nil {
+				return err
+			}
+		}
+
+		t := ""
+		if r.Time != nil {
+			t = r.Time.AsTime().Format(time.RFC3339Nano)
+		}
+		for _, tag := range r.Tags {
+			_, err := fmt.Fprintf(s.writer, "%d,%s,%d,%g,%g,%g\n", r.FrameID, t, tag.ID, tag.X, tag.Y, tag.Theta)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// An udpSink sends each frame readout as a size-delimited protobuf
+// message in its own datagram. The address could be a multicast
+// group.
+type udpSink struct {
+	address string
+	logger  *logrus.Entry
+}
+
+func newUDPSink(ctx context.Context, env *TrackingEnvironment, boundaries *segmentBoundaries, config leto.SinkConfiguration) (FrameSink, error) {
+	if _, err := net.ResolveUDPAddr("udp", config.Address); err != nil {
+		return nil, err
+	}
+	return &udpSink{
+		address: config.Address,
+		logger:  tm.NewLogger("udp-sink").WithContext(ctx).WithField("address", config.Address),
+	}, nil
+}
+
+func (s *udpSink) Run(incoming <-chan *hermes.FrameReadout) error {
+	conn, err := net.Dial("udp", s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	failures := 0
+	for r := range incoming {
+		buf := proto.NewBuffer(nil)
+		if err := buf.EncodeMessage(r); err != nil {
+			return err
+		}
+		if _, err := conn.Write(buf.Bytes()); err != nil {
+			// nobody may be listening, it should not stop the sink.
+			if failures == 0 {
+				s.logger.WithError(err).Warn("could not send frame")
+			}
+			failures += 1
+			continue
+		}
+		failures = 0
+	}
+	return nil
+}
+
+// An unixSink serves the broadcast stream on an unix socket. Its path
+// is used as is: a relative path is relative to the working directory
+// of leto, not to the experiment directory.
+type unixSink struct {
+	cancel      context.CancelFunc
+	broadcaster *hermesBroadcaster
+}
+
+// removeStaleSocket removes the socket left at path by a previous
+// run. Anything else at path is left untouched, and reported as an
+// error.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) == true {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("'%s' already exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
+
+func newUnixSink(ctx context.Context, env *TrackingEnvironment, boundaries *segmentBoundaries, config leto.SinkConfiguration) (FrameSink, error) {
+	if err := removeStaleSocket(config.Path); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	server := newServerWithListener(ctx, listener, "unix-sink", 1*time.Second)
+	return &unixSink{
+		cancel: cancel,
+		broadcaster: newHermesBroadcaster(server,
+			time.Duration(3.0*float64(time.Second)/(*env.Config.Camera.FPS)),
+			env.Config.Broadcast),
+	}, nil
+}
+
+func (s *unixSink) Run(incoming <-chan *hermes.FrameReadout) error {
+	errs := Start(s.broadcaster)
+	for r := range incoming {
+		s.broadcaster.Incoming() <- r
+	}
+	close(s.broadcaster.Incoming())
+	s.cancel()
+	return <-errs
+}
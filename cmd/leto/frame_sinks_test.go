@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	. "gopkg.in/check.v1"
+)
+
+type FrameSinksSuite struct {
+	env *TrackingEnvironment
+}
+
+var _ = Suite(&FrameSinksSuite{})
+
+func (s *FrameSinksSuite) SetUpTest(c *C) {
+	config := leto.RecommendedTrackingConfiguration()
+	s.env = &TrackingEnvironment{
+		Config:        &config,
+		ExperimentDir: c.MkDir(),
+	}
+}
+
+func (s *FrameSinksSuite) testReadouts() []*hermes.FrameReadout {
+	t := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	return []*hermes.FrameReadout{
+		{
+			FrameID: 1,
+			Time:    &timestamp.Timestamp{Seconds: t.Unix()},
+			Tags: []*hermes.Tag{
+				{ID: 1, X: 10.5, Y: 20.0, Theta: 0.5},
+				{ID: 2, X: 30.0, Y: 40.0, Theta: -1.0},
+			},
+		},
+		{
+			FrameID: 2,
+			Time:    &timestamp.Timestamp{Seconds: t.Unix(), Nanos: 125000000},
+			Tags:    []*hermes.Tag{{ID: 1, X: 11.0, Y: 21.0, Theta: 0.25}},
+		},
+	}
+}
+
+func (s *FrameSinksSuite) TestCheckSinks(c *C) {
+	c.Check(checkSinks(nil), IsNil)
+	c.Check(checkSinks([]leto.SinkConfiguration{
+		{Type: "csv", Path: "tags.csv"},
+		{Type: "udp", Address: "239.0.0.1:4010"},
+		{Type: "csv", Path: "other.csv", Name: "other"},
+	}), IsNil)
+
+	testdata := []struct {
+		Sinks    []leto.SinkConfiguration
+		Expected string
+	}{
+		{
+			[]leto.SinkConfiguration{{Type: "parquet"}},
+			"sink 0: unknown type 'parquet': available types are csv, udp, unix",
+		},
+		{
+			[]leto.SinkConfiguration{{Type: "csv"}},
+			"sink 0: csv sink requires a path",
+		},
+		{
+			[]leto.SinkConfiguration{{Type: "udp"}},
+			"sink 0: udp sink requires an address",
+		},
+		{
+			[]leto.SinkConfiguration{
+				{Type: "csv", Path: "a.csv", Name: "foo"},
+				{Type: "unix", Path: "/tmp/foo", Name: "foo"},
+			},
+			"sink 1: duplicated name 'foo'",
+		},
+	}
+	for _, d := range testdata {
+		c.Check(checkSinks(d.Sinks), ErrorMatches, d.Expected)
+	}
+}
+
+func (s *FrameSinksSuite) TestCSVSink(c *C) {
+	*s.env.Config.Sinks = []leto.SinkConfiguration{{Type: "csv", Path: "tags.csv"}}
+	sinks, err := NewFrameSinks(context.Background(), s.env, nil)
+	c.Assert(err, IsNil)
+	c.Assert(sinks, HasLen, 1)
+	c.Check(sinks[0].name, Equals, "csv-0")
+	c.Check(sinks[0].DispatchOutput().Lossless, Equals, true)
+
+	errs := Start(sinks[0])
+	for _, r := range s.testReadouts() {
+		sinks[0].incoming <- r
+	}
+	close(sinks[0].incoming)
+	c.Assert(<-errs, IsNil)
+
+	content, err := os.ReadFile(s.env.Path("tags.csv"))
+	c.Assert(err, IsNil)
+	c.Check(string(content), Equals, `frame_id,time,tag_id,x,y,angle
+1,2023-04-01T10:00:00Z,1,10.5,20,0.5
+1,2023-04-01T10:00:00Z,2,30,40,-1
+2,2023-04-01T10:00:00.125Z,1,11,21,0.25
+`)
+}
+
+func (s *FrameSinksSuite) TestCSVSinkAppendsOnResume(c *C) {
+	*s.env.Config.Sinks = []leto.SinkConfiguration{{Type: "csv", Path: "tags.csv"}}
+	// each run of the sinks is a run of the experiment, paused in
+	// between.
+	for _, r := range s.testReadouts() {
+		sinks, err := NewFrameSinks(context.Background(), s.env, nil)
+		c.Assert(err, IsNil)
+		errs := Start(sinks[0])
+		sinks[0].incoming <- r
+		close(sinks[0].incoming)
+		c.Assert(<-errs, IsNil)
+	}
+
+	content, err := os.ReadFile(s.env.Path("tags.csv"))
+	c.Assert(err, IsNil)
+	c.Check(string(content), Equals, `frame_id,time,tag_id,x,y,angle
+1,2023-04-01T10:00:00Z,1,10.5,20,0.5
+1,2023-04-01T10:00:00Z,2,30,40,-1
+2,2023-04-01T10:00:00.125Z,1,11,21,0.25
+`)
+}
+
+func (s *FrameSinksSuite) TestCSVSinkFollowsRotation(c *C) {
+	rotated := c.MkDir()
+	absolute := filepath.Join(c.MkDir(), "all.csv")
+	*s.env.Config.Sinks = []leto.SinkConfiguration{
+		{Type: "csv", Path: "tags.csv"},
+		{Type: "csv", Path: absolute},
+	}
+	boundaries := newSegmentBoundaries(context.Background(), time.Hour, 0.0, nil)
+	sinks, err := NewFrameSinks(context.Background(), s.env, boundaries)
+	c.Assert(err, IsNil)
+	c.Assert(sinks, HasLen, 2)
+	// published in place of boundaries.Run(), on a rotation.
+	boundaries.publish(segmentBoundary{FrameID: 2, Directory: rotated})
+
+	for _, sink := range sinks {
+		errs := Start(sink)
+		for _, r := range s.testReadouts() {
+			sink.incoming <- r
+		}
+		close(sink.incoming)
+		c.Assert(<-errs, IsNil)
+	}
+
+	for _, d := range []struct {
+		Path     string
+		Expected string
+	}{
+		{s.env.Path("tags.csv"), `frame_id,time,tag_id,x,y,angle
+1,2023-04-01T10:00:00Z,1,10.5,20,0.5
+1,2023-04-01T10:00:00Z,2,30,40,-1
+`},
+		{filepath.Join(rotated, "tags.csv"), `frame_id,time,tag_id,x,y,angle
+2,2023-04-01T10:00:00.125Z,1,11,21,0.25
+`},
+		{absolute, `frame_id,time,tag_id,x,y,angle
+1,2023-04-01T10:00:00Z,1,10.5,20,0.5
+1,2023-04-01T10:00:00Z,2,30,40,-1
+2,2023-04-01T10:00:00.125Z,1,11,21,0.25
+`},
+	} {
+		content, err := os.ReadFile(d.Path)
+		c.Assert(err, IsNil)
+		c.Check(string(content), Equals, d.Expected, Commentf("%s", d.Path))
+	}
+}
+
+func (s *FrameSinksSuite) TestUDPSink(c *C) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer listener.Close()
+
+	*s.env.Config.Sinks = []leto.SinkConfiguration{{Type: "udp", Address: listener.LocalAddr().String()}}
+	sinks, err := NewFrameSinks(context.Background(), s.env, nil)
+	c.Assert(err, IsNil)
+	c.Check(sinks[0].DispatchOutput().Lossless, Equals, false)
+
+	errs := Start(sinks[0])
+	for _, r := range s.testReadouts() {
+		sinks[0].incoming <- r
+	}
+	close(sinks[0].incoming)
+	c.Assert(<-errs, IsNil)
+
+	for _, expected := range []int64{1, 2} {
+		buf := make([]byte, 1024)
+		listener.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := listener.ReadFrom(buf)
+		c.Assert(err, IsNil)
+		ro := &hermes.FrameReadout{}
+		ok, err := hermes.ReadDelimitedMessage(bytes.NewReader(buf[:n]), ro)
+		c.Assert(err, IsNil)
+		c.Assert(ok, Equals, true)
+		c.Check(ro.FrameID, Equals, expected)
+	}
+}
+
+func (s *FrameSinksSuite) TestUnixSink(c *C) {
+	path := filepath.Join(c.MkDir(), "leto.sock")
+	*s.env.Config.Sinks = []leto.SinkConfiguration{{Type: "unix", Path: path}}
+	sinks, err := NewFrameSinks(context.Background(), s.env, nil)
+	c.Assert(err, IsNil)
+	errs := Start(sinks[0])
+
+	conn, err := net.Dial("unix", path)
+	c.Assert(err, IsNil)
+	defer conn.Close()
+	header := &hermes.Header{}
+	ok, err := hermes.ReadDelimitedMessage(conn, header)
+	c.Assert(err, IsNil)
+	c.Assert(ok, Equals, true)
+	// lets the client be registered.
+	time.Sleep(10 * time.Millisecond)
+
+	sinks[0].incoming <- s.testReadouts()[0]
+	ro := &hermes.FrameReadout{}
+	ok, err = hermes.ReadDelimitedMessage(conn, ro)
+	c.Assert(err, IsNil)
+	c.Assert(ok, Equals, true)
+	c.Check(ro.FrameID, Equals, int64(1))
+
+	close(sinks[0].incoming)
+	c.Check(<-errs, IsNil)
+	_, err = os.Stat(path)
+	c.Check(os.IsNotExist(err), Equals, true)
+}
+
+func (s *FrameSinksSuite) TestUnixSinkOnlyReplacesSockets(c *C) {
+	dir := c.MkDir()
+	socket := filepath.Join(dir, "stale.sock")
+	listener, err := net.Listen("unix", socket)
+	c.Assert(err, IsNil)
+	// leaves the socket file behind, as a crashed run would.
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	c.Assert(listener.Close(), IsNil)
+	regular := filepath.Join(dir, "tags.csv")
+	c.Assert(os.WriteFile(regular, []byte("precious"), 0644), IsNil)
+
+	c.Check(removeStaleSocket(socket), IsNil)
+	_, err = os.Stat(socket)
+	c.Check(os.IsNotExist(err), Equals, true)
+	c.Check(removeStaleSocket(socket), IsNil)
+
+	*s.env.Config.Sinks = []leto.SinkConfiguration{{Type: "unix", Path: regular}}
+	_, err = NewFrameSinks(context.Background(), s.env, nil)
+	c.Check(err, ErrorMatches, "could not create sink unix-0: '.*tags.csv' already exists and is not a socket")
+	content, err := os.ReadFile(regular)
+	c.Assert(err, IsNil)
+	c.Check(string(content), Equals, "precious")
+
+	*s.env.Config.Sinks = []leto.SinkConfiguration{{Type: "unix", Path: dir}}
+	_, err = NewFrameSinks(context.Background(), s.env, nil)
+	c.Check(err, ErrorMatches, "could not create sink unix-0: '.*' already exists and is not a socket")
+}
+
+type failingSink struct{}
+
+func (failingSink) Run(<-chan *hermes.FrameReadout) error {
+	return errors.New("oops")
+}
+
+func (s *FrameSinksSuite) TestFailedSinkDoesNotBlock(c *C) {
+	sink := &configuredSink{
+		name:     "failing",
+		lossless: true,
+		sink:     failingSink{},
+		incoming: make(chan *hermes.FrameReadout),
+	}
+	errs := Start(sink)
+	for _, r := range s.testReadouts() {
+		sink.incoming <- r
+	}
+	close(sink.incoming)
+	c.Check(<-errs, ErrorMatches, "oops")
+	c.Check(sink.discarded.Load(), Equals, int64(len(s.testReadouts())))
+}
@@ -2,18 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
+	"path"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
 	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type HermesBroadcaster interface {
 	Task
 	Incoming() chan<- *hermes.FrameReadout
+	Clients() []*letopb.BroadcastClient
 }
 
 type hermesBroadcaster struct {
@@ -21,9 +33,34 @@ type hermesBroadcaster struct {
 
 	server   *Server
 	incoming chan *hermes.FrameReadout
-	outgoing map[int]chan []byte
+	clients  map[int]*broadcastClient
 	idle     time.Duration
 	nextId   int
+
+	policy   string
+	maxDrops int
+}
+
+// A broadcastFrame is a FrameReadout to broadcast and its encoding,
+// which is shared by all clients without a subscription.
+type broadcastFrame struct {
+	readout *hermes.FrameReadout
+	data    []byte
+}
+
+// A broadcastClient is a connection to the broadcast stream.
+type broadcastClient struct {
+	address  string
+	since    time.Time
+	outgoing chan broadcastFrame
+	// closed when the client is evicted for being too slow.
+	evicted chan struct{}
+
+	dropped atomic.Int64
+
+	// only accessed by broadcastToAll.
+	consecutiveDrops int
+	evicting         bool
 }
 
 func (b *hermesBroadcaster) Incoming() chan<- *hermes.FrameReadout {
@@ -40,62 +77,148 @@ func (b *hermesBroadcaster) incomingLoop() {
 	for r := range b.incoming {
 		buf := proto.NewBuffer(nil)
 		buf.EncodeMessage(r)
-		b.broadcastToAll(buf.Bytes())
+		b.broadcastToAll(broadcastFrame{readout: r, data: buf.Bytes()})
 	}
 }
 
-func (b *hermesBroadcaster) broadcastToAll(data []byte) {
+func (b *hermesBroadcaster) broadcastToAll(frame broadcastFrame) {
 	b.mx.RLock()
 	defer b.mx.RUnlock()
 
-	for _, ch := range b.outgoing {
-		select {
-		case ch <- data:
-		default:
+	for _, c := range b.clients {
+		if b.send(c, frame) == true {
+			c.consecutiveDrops = 0
 			continue
 		}
+
+		c.dropped.Add(1)
+		c.consecutiveDrops += 1
+		if b.policy == leto.BroadcastDisconnect &&
+			c.consecutiveDrops >= b.maxDrops &&
+			c.evicting == false {
+			c.evicting = true
+			close(c.evicted)
+		}
+	}
+}
+
+// send sends frame to a client, and returns false if a frame was
+// dropped, according to the policy.
+func (b *hermesBroadcaster) send(c *broadcastClient, frame broadcastFrame) bool {
+	select {
+	case c.outgoing <- frame:
+		return true
+	default:
+	}
+
+	if b.policy != leto.BroadcastDropOldest {
+		return false
+	}
+
+	dropped := false
+	select {
+	case <-c.outgoing:
+		dropped = true
+	default:
+	}
+	// we are the only sender, there is room for frame now.
+	select {
+	case c.outgoing <- frame:
+	default:
+		dropped = true
 	}
+	return dropped == false
 }
 
 func (b *hermesBroadcaster) closeAllOutgoing() {
 	b.mx.Lock()
 	defer b.mx.Unlock()
 
-	for _, ch := range b.outgoing {
-		close(ch)
+	for _, c := range b.clients {
+		close(c.outgoing)
 	}
-	b.outgoing = nil
+	b.clients = nil
 }
 
-func NewHermesBroadcaster(ctx context.Context, port int, idle time.Duration) (HermesBroadcaster, error) {
+// Clients returns the currently connected clients, the oldest first.
+func (b *hermesBroadcaster) Clients() []*letopb.BroadcastClient {
+	b.mx.RLock()
+	defer b.mx.RUnlock()
+
+	res := make([]*letopb.BroadcastClient, 0, len(b.clients))
+	for _, c := range b.clients {
+		res = append(res, &letopb.BroadcastClient{
+			Address: c.address,
+			Since:   timestamppb.New(c.since),
+			Dropped: c.dropped.Load(),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Since.AsTime().Before(res[j].Since.AsTime())
+	})
+	return res
+}
+
+func (b *hermesBroadcaster) reportDropped(_ context.Context, obs metric.Int64Observer) error {
+	b.mx.RLock()
+	defer b.mx.RUnlock()
+
+	for _, c := range b.clients {
+		obs.Observe(c.dropped.Load(), metric.WithAttributes(attribute.String("address", c.address)))
+	}
+	return nil
+}
+
+// NewHermesBroadcaster creates a HermesBroadcaster serving on
+// port. Clients too slow to receive all frames are handled according
+// to config.
+func NewHermesBroadcaster(ctx context.Context, port int, idle time.Duration, config leto.BroadcastConfiguration) (HermesBroadcaster, error) {
 	server, err := NewServer(ctx, port, "broadcast", 1*time.Second)
 	if err != nil {
 		return nil, err
 	}
+	return newHermesBroadcaster(server, idle, config), nil
+}
+
+func newHermesBroadcaster(server *Server, idle time.Duration, config leto.BroadcastConfiguration) *hermesBroadcaster {
 	res := &hermesBroadcaster{
 		server:   server,
 		incoming: make(chan *hermes.FrameReadout, 10),
-		outgoing: make(map[int]chan []byte),
+		clients:  make(map[int]*broadcastClient),
 		idle:     idle,
+		policy:   *config.Policy,
+		maxDrops: *config.MaxConsecutiveDrops,
 	}
 	res.server.onAccept = res.onAccept
-	return res, nil
+
+	otel.Meter(instrumentationName).
+		Int64ObservableCounter(path.Join("leto", "broadcastDropped"),
+			metric.WithInt64Callback(res.reportDropped),
+		)
+
+	return res
 }
 
-func (h *hermesBroadcaster) registerNew() (int, <-chan []byte) {
+func (h *hermesBroadcaster) registerNew(address string) (int, *broadcastClient) {
 	h.mx.Lock()
 	defer h.mx.Unlock()
 	id := h.nextId
 	h.nextId += 1
 
-	h.outgoing[id] = make(chan []byte, 10)
-	return id, h.outgoing[id]
+	c := &broadcastClient{
+		address:  address,
+		since:    time.Now(),
+		outgoing: make(chan broadcastFrame, 10),
+		evicted:  make(chan struct{}),
+	}
+	h.clients[id] = c
+	return id, c
 }
 
 func (h *hermesBroadcaster) unregister(id int) {
 	h.mx.Lock()
 	defer h.mx.Unlock()
-	delete(h.outgoing, id)
+	delete(h.clients, id)
 }
 
 func (h *hermesBroadcaster) onAccept(ctx context.Context, conn net.Conn) {
@@ -111,13 +234,43 @@ func (h *hermesBroadcaster) onAccept(ctx context.Context, conn net.Conn) {
 		return
 	}
 
-	id, outgoing := h.registerNew()
+	id, client := h.registerNew(conn.RemoteAddr().String())
 	defer h.unregister(id)
 
+	var filter atomic.Pointer[broadcastFilter]
+	go readSubscriptions(conn, &filter, logger)
+
 	logger.Info("started data stream")
 
-	for data := range outgoing {
-		conn.SetDeadline(time.Now().Add(h.idle))
+	var received uint64 = 0
+	for {
+		var frame broadcastFrame
+		var ok bool
+		select {
+		case <-client.evicted:
+			logger.WithField("dropped", client.dropped.Load()).Warn("disconnecting slow client")
+			return
+		case frame, ok = <-client.outgoing:
+		}
+		if ok == false {
+			break
+		}
+
+		data := frame.data
+		if f := filter.Load(); f != nil {
+			ro := f.Filter(received, frame.readout)
+			received += 1
+			if ro == nil {
+				continue
+			}
+			buf := proto.NewBuffer(nil)
+			buf.EncodeMessage(ro)
+			data = buf.Bytes()
+		}
+
+		// only the write deadline is set, as subscriptions could
+		// be received at any time.
+		conn.SetWriteDeadline(time.Now().Add(h.idle))
 		_, err := conn.Write(data)
 		if err != nil {
 			logger.WithError(err).Error("could not write data")
@@ -142,3 +295,87 @@ func (h *hermesBroadcaster) writeHeader(w io.Writer) error {
 	_, err := w.Write(buf.Bytes())
 	return err
 }
+
+// readSubscriptions reads the BroadcastSubscription sent by a client
+// until its connection is closed, and stores the corresponding
+// filter.
+func readSubscriptions(conn net.Conn, filter *atomic.Pointer[broadcastFilter], logger *logrus.Entry) {
+	for {
+		subscription := &letopb.BroadcastSubscription{}
+		ok, err := hermes.ReadDelimitedMessage(conn, subscription)
+		if err != nil {
+			if errors.Is(err, io.EOF) == false && errors.Is(err, net.ErrClosed) == false {
+				logger.WithError(err).Warn("could not read subscription")
+			}
+			return
+		}
+		if ok == false {
+			// an empty subscription is encoded as an empty message.
+			subscription.Reset()
+		}
+		logger.WithFields(logrus.Fields{
+			"tags":        subscription.TagIds,
+			"decimation":  subscription.Decimation,
+			"counts-only": subscription.CountsOnly,
+		}).Info("new subscription")
+		filter.Store(newBroadcastFilter(subscription))
+	}
+}
+
+// A broadcastFilter tailors the stream of a client to its
+// BroadcastSubscription.
+type broadcastFilter struct {
+	tags       map[uint32]bool
+	decimation uint64
+	countsOnly bool
+}
+
+// newBroadcastFilter returns the filter for subscription, or nil if
+// the subscription asks for the full stream.
+func newBroadcastFilter(subscription *letopb.BroadcastSubscription) *broadcastFilter {
+	if len(subscription.TagIds) == 0 &&
+		subscription.Decimation <= 1 &&
+		subscription.CountsOnly == false {
+		return nil
+	}
+
+	res := &broadcastFilter{
+		decimation: 1,
+		countsOnly: subscription.CountsOnly,
+	}
+	if subscription.Decimation > 1 {
+		res.decimation = uint64(subscription.Decimation)
+	}
+	if len(subscription.TagIds) > 0 {
+		res.tags = make(map[uint32]bool)
+		for _, id := range subscription.TagIds {
+			res.tags[id] = true
+		}
+	}
+	return res
+}
+
+// Filter returns the readout to send for the idx-th frame received by
+// a client, or nil if the frame should be skipped. ro is never
+// modified.
+func (f *broadcastFilter) Filter(idx uint64, ro *hermes.FrameReadout) *hermes.FrameReadout {
+	if idx%f.decimation != 0 {
+		return nil
+	}
+	if f.tags == nil && f.countsOnly == false {
+		return ro
+	}
+
+	res := *ro
+	res.Tags = make([]*hermes.Tag, 0, len(ro.Tags))
+	for _, t := range ro.Tags {
+		if f.tags != nil && f.tags[t.ID] == false {
+			continue
+		}
+		if f.countsOnly == true {
+			t = &hermes.Tag{ID: t.ID}
+		}
+		res.Tags = append(res.Tags, t)
+	}
+	return &res
+}
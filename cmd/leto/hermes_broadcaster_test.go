@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/golang/protobuf/proto"
 	. "gopkg.in/check.v1"
 )
 
@@ -21,7 +24,7 @@ func (s *HermesBroadcasterSuite) SetUpTest(c *C) {
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
 	var err error
-	s.broadcaster, err = NewHermesBroadcaster(ctx, 12345, 30*time.Millisecond)
+	s.broadcaster, err = NewHermesBroadcaster(ctx, 12345, 30*time.Millisecond, leto.RecommendedBroadcastConfiguration())
 	c.Assert(err, IsNil)
 
 	s.err = Start(s.broadcaster)
@@ -83,3 +86,138 @@ func (s *HermesBroadcasterSuite) TestNatsyDisconnectingClientMustNotPanic(c *C)
 	}
 
 }
+
+func (s *HermesBroadcasterSuite) TestSubscription(c *C) {
+	conn, err := net.Dial("tcp", "localhost:12345")
+	c.Assert(err, IsNil)
+	defer conn.Close()
+
+	h := hermes.Header{}
+	ok, err := hermes.ReadDelimitedMessage(conn, &h)
+	c.Assert(ok, Equals, true)
+	c.Assert(err, IsNil)
+
+	buf := proto.NewBuffer(nil)
+	c.Assert(buf.EncodeMessage(&letopb.BroadcastSubscription{
+		TagIds:     []uint32{2},
+		Decimation: 2,
+		CountsOnly: true,
+	}), IsNil)
+	_, err = conn.Write(buf.Bytes())
+	c.Assert(err, IsNil)
+	// the subscription is read asynchronously.
+	time.Sleep(20 * time.Millisecond)
+
+	for i := 1; i <= 4; i++ {
+		s.broadcaster.Incoming() <- &hermes.FrameReadout{
+			FrameID: int64(i),
+			Tags: []*hermes.Tag{
+				{ID: 1, X: 10.0, Y: 10.0},
+				{ID: 2, X: 20.0, Y: 20.0},
+				{ID: 3, X: 30.0, Y: 30.0},
+			},
+		}
+	}
+
+	for _, expected := range []int64{1, 3} {
+		ro := hermes.FrameReadout{}
+		ok, err = hermes.ReadDelimitedMessage(conn, &ro)
+		c.Assert(err, IsNil)
+		c.Assert(ok, Equals, true)
+		c.Check(ro.FrameID, Equals, expected)
+		c.Assert(ro.Tags, HasLen, 1)
+		c.Check(ro.Tags[0].ID, Equals, uint32(2))
+		c.Check(ro.Tags[0].X, Equals, 0.0)
+	}
+}
+
+func (s *HermesBroadcasterSuite) TestBroadcastFilter(c *C) {
+	c.Check(newBroadcastFilter(&letopb.BroadcastSubscription{Decimation: 1}), IsNil)
+
+	ro := &hermes.FrameReadout{
+		FrameID: 1,
+		Tags:    []*hermes.Tag{{ID: 1, X: 10.0}, {ID: 2, X: 20.0}},
+	}
+
+	f := newBroadcastFilter(&letopb.BroadcastSubscription{Decimation: 3})
+	c.Assert(f, NotNil)
+	c.Check(f.Filter(0, ro), Equals, ro)
+	c.Check(f.Filter(1, ro), IsNil)
+	c.Check(f.Filter(2, ro), IsNil)
+	c.Check(f.Filter(3, ro), Equals, ro)
+
+	f = newBroadcastFilter(&letopb.BroadcastSubscription{TagIds: []uint32{1}})
+	res := f.Filter(1, ro)
+	c.Assert(res.Tags, HasLen, 1)
+	c.Check(res.Tags[0], Equals, ro.Tags[0])
+	c.Check(ro.Tags, HasLen, 2)
+
+	f = newBroadcastFilter(&letopb.BroadcastSubscription{CountsOnly: true})
+	res = f.Filter(1, ro)
+	c.Assert(res.Tags, HasLen, 2)
+	c.Check(res.Tags[1].ID, Equals, uint32(2))
+	c.Check(res.Tags[1].X, Equals, 0.0)
+	c.Check(ro.Tags[1].X, Equals, 20.0)
+}
+
+func (s *HermesBroadcasterSuite) TestSlowClientPolicies(c *C) {
+	config := leto.RecommendedBroadcastConfiguration()
+	b := &hermesBroadcaster{
+		clients:  make(map[int]*broadcastClient),
+		maxDrops: 3,
+	}
+	frame := func(i int64) broadcastFrame {
+		return broadcastFrame{readout: &hermes.FrameReadout{FrameID: i}}
+	}
+	newClient := func() *broadcastClient {
+		res := &broadcastClient{
+			outgoing: make(chan broadcastFrame, 2),
+			evicted:  make(chan struct{}),
+		}
+		b.clients[0] = res
+		return res
+	}
+
+	b.policy = *config.Policy
+	client := newClient()
+	for i := int64(0); i < 4; i++ {
+		b.broadcastToAll(frame(i))
+	}
+	c.Check(client.dropped.Load(), Equals, int64(2))
+	c.Check((<-client.outgoing).readout.FrameID, Equals, int64(0))
+	c.Check((<-client.outgoing).readout.FrameID, Equals, int64(1))
+
+	b.policy = leto.BroadcastDropOldest
+	client = newClient()
+	for i := int64(0); i < 4; i++ {
+		b.broadcastToAll(frame(i))
+	}
+	c.Check(client.dropped.Load(), Equals, int64(2))
+	c.Check((<-client.outgoing).readout.FrameID, Equals, int64(2))
+	c.Check((<-client.outgoing).readout.FrameID, Equals, int64(3))
+
+	b.policy = leto.BroadcastDisconnect
+	client = newClient()
+	for i := int64(0); i < 4; i++ {
+		b.broadcastToAll(frame(i))
+	}
+	select {
+	case <-client.evicted:
+		c.Errorf("client should not be evicted after 2 drops")
+	default:
+	}
+	b.broadcastToAll(frame(4))
+	select {
+	case <-client.evicted:
+	default:
+		c.Errorf("client should be evicted after 3 drops")
+	}
+	// must not close twice
+	b.broadcastToAll(frame(5))
+	c.Check(client.dropped.Load(), Equals, int64(4))
+
+	clients := b.Clients()
+	c.Assert(clients, HasLen, 1)
+	c.Check(clients[0].Dropped, Equals, int64(4))
+	b.clients = nil
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
+	"sync"
+	"sync/atomic"
 
 	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
 	"github.com/formicidae-tracker/olympus/pkg/tm"
 	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
@@ -17,25 +19,47 @@ import (
 type HermesFileWriter interface {
 	Task
 	Incoming() chan<- *hermes.FrameReadout
+	// Discarded counts the frames discarded after a failure.
+	Discarded() *atomic.Int64
 }
 
 type hermesFileWriter struct {
-	period                         time.Duration
+	boundaries                     *segmentBoundaries
+	pending                        segmentBoundaryQueue
+	maxBytes                       int64
+	written                        atomic.Int64
 	basename                       string
 	lastname, lastUncompressedName string
-	file, uncompressed             *os.File
-	gzip                           *gzip.Writer
-	logger                         *logrus.Entry
-	incoming                       chan *hermes.FrameReadout
+	// unlinked is the last segment of a paused experiment, whose
+	// footer should link to the first segment written.
+	unlinked string
+	// linking waits for the rewrite of unlinked, performed in the
+	// background as it could take a while for large segments.
+	linking            sync.WaitGroup
+	file, uncompressed *os.File
+	gzip               *gzip.Writer
+	logger             *logrus.Entry
+	incoming           chan *hermes.FrameReadout
+	discarded          atomic.Int64
 }
 
-func NewFrameReadoutWriter(ctx context.Context, filepath string) (HermesFileWriter, error) {
+// NewFrameReadoutWriter creates a HermesFileWriter writing segments
+// named after filepath. A new segment is started on each frame
+// boundary decided by boundaries, and a boundary is requested once a
+// segment reaches the maximal size in config. A boundary carrying a
+// directory starts a new, independent, sequence of segments in that
+// directory. boundaries could be nil. If segments were already
+// written, as when a paused experiment is resumed, the writer
+// continues their chain.
+func NewFrameReadoutWriter(ctx context.Context, filepath string, config leto.OutputConfiguration, boundaries *segmentBoundaries) (HermesFileWriter, error) {
 
 	return &hermesFileWriter{
-		period:   2 * time.Hour,
-		basename: filepath,
-		logger:   tm.NewLogger("file-writer").WithContext(ctx),
-		incoming: make(chan *hermes.FrameReadout, 200),
+		boundaries: boundaries,
+		pending:    newSegmentBoundaryQueue(boundaries),
+		maxBytes:   int64(*config.HermesMaxSizeMB) * 1024 * 1024,
+		basename:   filepath,
+		logger:     tm.NewLogger("file-writer").WithContext(ctx),
+		incoming:   make(chan *hermes.FrameReadout, 200),
 	}, nil
 
 }
@@ -44,6 +68,10 @@ func (w *hermesFileWriter) Incoming() chan<- *hermes.FrameReadout {
 	return w.incoming
 }
 
+func (w *hermesFileWriter) Discarded() *atomic.Int64 {
+	return &w.discarded
+}
+
 func (w *hermesFileWriter) openFile(filename, filenameUncompressed string, width, height int32) error {
 	var err error
 	w.file, err = os.Create(filename)
@@ -55,7 +83,8 @@ func (w *hermesFileWriter) openFile(filename, filenameUncompressed string, width
 		return err
 	}
 
-	w.gzip = gzip.NewWriter(w.file)
+	w.written.Store(0)
+	w.gzip = gzip.NewWriter(countingWriter{w: w.file, count: &w.written})
 
 	header := &hermes.Header{
 		Type: hermes.Header_File,
@@ -199,6 +228,7 @@ func (w *hermesFileWriter) writeLine(r *hermes.FrameReadout, nextName string) er
 		if err != nil {
 			return err
 		}
+		w.linkUnlinked(nextName)
 	}
 
 	// makes a semi-shallow copy to strip away unucessary
@@ -233,6 +263,29 @@ func (w *hermesFileWriter) writeLine(r *hermes.FrameReadout, nextName string) er
 	return nil
 }
 
+// linkUnlinked links, in the background, the last segment of a
+// paused experiment to the segment now written. The whole segment is
+// rewritten, which should not delay incoming frames. A failure only
+// breaks the chain, the frames are kept.
+func (w *hermesFileWriter) linkUnlinked(nextName string) {
+	if len(w.unlinked) == 0 {
+		return
+	}
+	segment := w.unlinked
+	w.unlinked = ""
+	w.linking.Add(1)
+	go func() {
+		defer w.linking.Done()
+		if err := leto.LinkHermesSegment(segment, nextName); err != nil {
+			w.logger.WithFields(logrus.Fields{
+				"segment": segment,
+				"next":    nextName,
+				"error":   err,
+			}).Error("could not link previous segment")
+		}
+	}()
+}
+
 func (w *hermesFileWriter) closeAndGetNextName() (string, error) {
 	nextName, _, err := FilenameWithoutOverwrite(w.basename)
 	if err != nil {
@@ -241,43 +294,89 @@ func (w *hermesFileWriter) closeAndGetNextName() (string, error) {
 	return nextName, w.closeFiles(nextName)
 }
 
+// Run writes the incoming frames until Incoming() is closed. If it
+// fails, the remaining frames are discarded in the background, as the
+// dispatcher waits for this lossless output to accept them, and
+// counted in Discarded(). It
+// returns once the previous segment of a paused experiment is linked.
 func (w *hermesFileWriter) Run() (retError error) {
-	ticker := time.NewTicker(w.period)
+	defer w.linking.Wait()
 	defer func() {
-		ticker.Stop()
 		err := w.closeFiles("")
 		if retError == nil {
 			retError = err
 		}
+		if retError != nil {
+			go func() {
+				for range w.incoming {
+					w.discarded.Add(1)
+				}
+			}()
+		}
 	}()
 
-	closeNext := false
-	nextName, _, err := FilenameWithoutOverwrite(w.basename)
+	requested := false
+	nextName, iter, err := FilenameWithoutOverwrite(w.basename)
 	if err != nil {
 		return fmt.Errorf("could not find unique name: %w", err)
 	}
+	if iter > 0 {
+		w.lastname = FilenameWithSuffix(w.basename, iter-1)
+		w.unlinked = w.lastname
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			closeNext = true
-		case r, ok := <-w.incoming:
-			if ok == false {
-				return nil
-			}
-			if err := w.writeLine(r, nextName); err != nil {
-				return err
-			}
-
-			if closeNext == false {
-				continue
+	for r := range w.incoming {
+		// the segment is only closed once the boundary frame
+		// arrives, so its footer never points to a segment that will
+		// never be written.
+		reached, dir := w.pending.Reached(r.FrameID)
+		if len(dir) > 0 {
+			requested = false
+			nextName, err = w.moveTo(dir)
+			if err != nil {
+				return w.discard(err)
 			}
-
-			closeNext = false
+		} else if reached == true && w.file != nil {
+			requested = false
 			nextName, err = w.closeAndGetNextName()
 			if err != nil {
-				return err
+				return w.discard(err)
 			}
 		}
+
+		if err := w.writeLine(r, nextName); err != nil {
+			return w.discard(err)
+		}
+
+		if w.boundaries != nil && requested == false &&
+			w.maxBytes > 0 && w.written.Load() >= w.maxBytes {
+			requested = true
+			w.boundaries.Request()
+		}
+	}
+	return nil
+}
+
+// discard counts the frame which could not be written because of err,
+// and returns err.
+func (w *hermesFileWriter) discard(err error) error {
+	w.discarded.Add(1)
+	return err
+}
+
+// moveTo closes the current segment and returns the name of the first
+// segment in dir, which starts its own sequence of segments.
+func (w *hermesFileWriter) moveTo(dir string) (string, error) {
+	w.logger.WithField("directory", dir).Info("moving segments to new experiment directory")
+	w.basename = filepath.Join(dir, filepath.Base(w.basename))
+	if err := w.closeFiles(""); err != nil {
+		return "", err
+	}
+	w.lastname = ""
+	w.unlinked = ""
+	nextName, _, err := FilenameWithoutOverwrite(w.basename)
+	if err != nil {
+		return "", fmt.Errorf("could not find unique name: %w", err)
 	}
+	return nextName, nil
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
 	. "gopkg.in/check.v1"
 )
 
@@ -15,6 +16,7 @@ type FileWriterSuite struct {
 	basedir string
 	writer  HermesFileWriter
 	err     <-chan error
+	cancel  context.CancelFunc
 }
 
 var _ = Suite(&FileWriterSuite{})
@@ -32,12 +34,19 @@ func (s *FileWriterSuite) TearDownSuite(c *C) {
 
 func (s *FileWriterSuite) SetUpTest(c *C) {
 	var err error
-	s.writer, err = NewFrameReadoutWriter(context.Background(), filepath.Join(s.basedir, c.TestName()+".hermes"))
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	boundaries := newSegmentBoundaries(ctx, 5*time.Millisecond, 0.0, nil)
+	s.writer, err = NewFrameReadoutWriter(ctx, filepath.Join(s.basedir, c.TestName()+".hermes"), leto.RecommendedOutputConfiguration(), boundaries)
 	c.Assert(err, IsNil)
-	s.writer.(*hermesFileWriter).period = 5 * time.Millisecond
+	go boundaries.Run(ctx)
 	s.err = Start(s.writer)
 }
 
+func (s *FileWriterSuite) TearDownTest(c *C) {
+	s.cancel()
+}
+
 func (s *FileWriterSuite) TestNothingHappenWouldClose(c *C) {
 	close(s.writer.Incoming())
 
@@ -79,3 +88,120 @@ func (s *FileWriterSuite) TestE2E(c *C) {
 	}
 
 }
+
+func (s *FileWriterSuite) TestRotation(c *C) {
+	close(s.writer.Incoming())
+	c.Check(<-s.err, IsNil)
+
+	first := filepath.Join(s.basedir, c.TestName()+"-0")
+	second := filepath.Join(s.basedir, c.TestName()+"-1")
+	c.Assert(os.MkdirAll(first, 0755), IsNil)
+	c.Assert(os.MkdirAll(second, 0755), IsNil)
+
+	boundaries := newSegmentBoundaries(context.Background(), time.Hour, 0.0, nil)
+	writer, err := NewFrameReadoutWriter(context.Background(), filepath.Join(first, "tracking.hermes"), leto.RecommendedOutputConfiguration(), boundaries)
+	c.Assert(err, IsNil)
+	// published in place of boundaries.Run(), on a rotation.
+	boundaries.publish(segmentBoundary{FrameID: 3, Directory: second})
+	errs := Start(writer)
+
+	for i := 1; i <= 4; i++ {
+		writer.Incoming() <- &hermes.FrameReadout{FrameID: int64(i)}
+	}
+	close(writer.Incoming())
+	c.Check(<-errs, IsNil)
+
+	for _, dir := range []string{first, second} {
+		report, err := leto.VerifyHermesDirectory(dir)
+		c.Assert(err, IsNil)
+		c.Check(report.Ok(), Equals, true)
+		c.Assert(report.Segments, HasLen, 1)
+		// each directory is an independent sequence of segments.
+		c.Check(report.Segments[0].Previous, Equals, "")
+		c.Check(report.Segments[0].Next, Equals, "")
+		c.Check(report.Frames(), Equals, 2)
+	}
+}
+
+func (s *FileWriterSuite) TestRotatesOnSize(c *C) {
+	close(s.writer.Incoming())
+	c.Check(<-s.err, IsNil)
+
+	dir := filepath.Join(s.basedir, c.TestName())
+	c.Assert(os.MkdirAll(dir, 0755), IsNil)
+	boundaries := newSegmentBoundaries(context.Background(), time.Hour, 0.0, nil)
+	writer, err := NewFrameReadoutWriter(context.Background(), filepath.Join(dir, "tracking.hermes"), leto.RecommendedOutputConfiguration(), boundaries)
+	c.Assert(err, IsNil)
+	// the gzip header is enough to fill a segment.
+	writer.(*hermesFileWriter).maxBytes = 1
+	errs := Start(writer)
+
+	for i := 0; i < 3; i++ {
+		writer.Incoming() <- &hermes.FrameReadout{FrameID: int64(i)}
+		if i == 2 {
+			break
+		}
+		// answers the request in place of boundaries.Run(), before
+		// the next frame is sent.
+		<-boundaries.requests
+		boundaries.publish(segmentBoundary{FrameID: int64(i + 1)})
+	}
+	close(writer.Incoming())
+	c.Check(<-errs, IsNil)
+
+	report, err := leto.VerifyHermesDirectory(dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, true)
+	c.Check(report.Segments, HasLen, 3)
+}
+
+func (s *FileWriterSuite) TestContinuesPausedChain(c *C) {
+	close(s.writer.Incoming())
+	c.Check(<-s.err, IsNil)
+
+	dir := filepath.Join(s.basedir, c.TestName())
+	c.Assert(os.MkdirAll(dir, 0755), IsNil)
+
+	// each writer is a run of the experiment, paused in between. The
+	// frame IDs continue across runs.
+	for run := int64(0); run < 2; run++ {
+		writer, err := NewFrameReadoutWriter(context.Background(), filepath.Join(dir, "tracking.hermes"), leto.RecommendedOutputConfiguration(), nil)
+		c.Assert(err, IsNil)
+		errs := Start(writer)
+		writer.Incoming() <- &hermes.FrameReadout{FrameID: 2 * run}
+		writer.Incoming() <- &hermes.FrameReadout{FrameID: 2*run + 1}
+		close(writer.Incoming())
+		c.Check(<-errs, IsNil)
+	}
+
+	report, err := leto.VerifyHermesDirectory(dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, true)
+	c.Assert(report.Segments, HasLen, 2)
+	c.Check(report.Segments[0].Next, Equals, "tracking.0001.hermes")
+	c.Check(report.Segments[1].Previous, Equals, "tracking.0000.hermes")
+	c.Check(report.Frames(), Equals, 4)
+}
+
+func (s *FileWriterSuite) TestDrainsAfterFailure(c *C) {
+	close(s.writer.Incoming())
+	c.Check(<-s.err, IsNil)
+
+	writer, err := NewFrameReadoutWriter(context.Background(), filepath.Join(s.basedir, c.TestName(), "does-not-exist", "tracking.hermes"), leto.RecommendedOutputConfiguration(), nil)
+	c.Assert(err, IsNil)
+	errs := Start(writer)
+	writer.Incoming() <- &hermes.FrameReadout{FrameID: 0}
+	c.Check(<-errs, Not(IsNil))
+
+	// more frames than the channel could buffer are still accepted.
+	for i := 1; i <= 400; i++ {
+		writer.Incoming() <- &hermes.FrameReadout{FrameID: int64(i)}
+	}
+	close(writer.Incoming())
+
+	// all frames, including the failed one, are counted.
+	for i := 0; i < 100 && writer.Discarded().Load() < 401; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	c.Check(writer.Discarded().Load(), Equals, int64(401))
+}
@@ -34,9 +34,19 @@ type Leto struct {
 	cancel context.CancelFunc
 
 	env        *TrackingEnvironment
+	runner     ExperimentRunner
 	runnerCond *sync.Cond
 
-	lastExperimentLog *letopb.ExperimentLog
+	history *experimentHistory
+
+	scheduler       *experimentScheduler
+	runningSchedule int
+
+	presets *presetStore
+
+	statusWatchers map[int]chan struct{}
+	nextWatcherID  int
+	watchPeriod    time.Duration
 
 	logger *logrus.Entry
 	tracer trace.Tracer
@@ -47,10 +57,13 @@ var instrumentationName string = "github.com/formicidae-tracker/leto/cmd/leto"
 
 func NewLeto(config leto.Config) (*Leto, error) {
 	l := &Leto{
-		leto:   config,
-		node:   GetNodeConfiguration(),
-		logger: tm.NewLogger("leto"),
-		tracer: otel.Tracer(instrumentationName),
+		leto:            config,
+		node:            GetNodeConfiguration(),
+		statusWatchers:  make(map[int]chan struct{}),
+		watchPeriod:     5 * time.Second,
+		runningSchedule: -1,
+		logger:          tm.NewLogger("leto"),
+		tracer:          otel.Tracer(instrumentationName),
 	}
 	l.runnerCond = sync.NewCond(&l.mx)
 	if err := l.check(); err != nil {
@@ -64,10 +77,45 @@ func NewLeto(config leto.Config) (*Leto, error) {
 		return nil, err
 	}
 
+	l.history, err = newExperimentHistory(l.historyDirPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// must be done before any experiment is restarted, as it would
+	// create new uncompressed segments.
+	l.recoverHermesSegments()
+
+	l.loadPresets()
 	l.LoadFromPersistentFile()
+	l.loadSchedules()
 	return l, nil
 }
 
+// recoverHermesSegments rebuilds any hermes segment that was not
+// properly closed because of a crash or a power loss.
+func (l *Leto) recoverHermesSegments() {
+	dirs, err := filepath.Glob(filepath.Join(xdg.DataHome, "fort-experiments", "*"))
+	if err != nil {
+		l.logger.WithError(err).Error("could not list experiment directories")
+		return
+	}
+	for _, dir := range dirs {
+		recovered, err := leto.RecoverHermesDirectory(dir)
+		for _, r := range recovered {
+			l.logger.WithFields(logrus.Fields{
+				"segment":   r.Segment,
+				"frames":    r.Frames,
+				"next":      r.Next,
+				"truncated": r.Truncated,
+			}).Warn("recovered unclosed hermes segment")
+		}
+		if err != nil {
+			l.logger.WithField("directory", dir).WithError(err).Error("could not recover hermes segments")
+		}
+	}
+}
+
 func (l *Leto) reportLoadAverage() {
 	otel.Meter(instrumentationName).Float64ObservableGauge(
 		path.Join("leto", "loadAverage"),
@@ -151,13 +199,36 @@ func (l *Leto) status(ctx context.Context) *letopb.Status {
 		l.logger.WithContext(ctx).WithError(err).Error("could not generate yaml config")
 	}
 	res.Experiment = &letopb.ExperimentStatus{
-		ExperimentDir:     filepath.Base(l.env.ExperimentDir),
-		YamlConfiguration: string(yamlConfig),
-		Since:             timestamppb.New(l.env.Start),
+		ExperimentDir:           filepath.Base(l.env.CurrentExperimentDir()),
+		YamlConfiguration:       string(yamlConfig),
+		Since:                   timestamppb.New(l.env.Start),
+		ConfigurationProvenance: l.env.Provenance,
+		PausedSince:             l.env.PausedSince(),
+	}
+	if l.env.Dispatcher != nil {
+		res.Experiment.DroppedFrames = l.env.Dispatcher.Dropped()
+	}
+	if l.node.IsMaster() == true && len(l.env.Node.Slaves) > 0 {
+		l.addClockSynchronizationToStatus(res.Experiment)
 	}
 	return res
 }
 
+func (l *Leto) addClockSynchronizationToStatus(status *letopb.ExperimentStatus) {
+	lastSync, producers := l.env.Balancing.Synchronization()
+	if lastSync.IsZero() == false {
+		status.LastMasterSync = timestamppb.New(lastSync)
+	}
+	for _, p := range producers {
+		status.ClockOffsets = append(status.ClockOffsets, &letopb.ClockSynchronization{
+			Producer: p.Producer,
+			OffsetUs: p.Offset,
+			DriftUs:  p.Drift,
+			JitterUs: p.Jitter,
+		})
+	}
+}
+
 func (l *Leto) addDiskInfoToStatus(ctx context.Context, status *letopb.Status) {
 	var err error
 	defer func() {
@@ -175,10 +246,97 @@ func (l *Leto) addDiskInfoToStatus(ctx context.Context, status *letopb.Status) {
 	status.FreeBytes, status.TotalBytes, status.BytesPerSecond, err = l.env.WatchDisk(time.Now())
 }
 
-func (l *Leto) LastExperimentLog() *letopb.ExperimentLog {
+// WatchStatus calls send with the current status, and then again
+// every time the experiment starts or stops, the node links are
+// modified, or the disk usage changes significantly. It returns when
+// ctx is done or send returns an error.
+func (l *Leto) WatchStatus(ctx context.Context, send func(*letopb.Status) error) error {
+	id, changes := l.registerStatusWatcher()
+	defer l.unregisterStatusWatcher(id)
+
+	ticker := time.NewTicker(l.watchPeriod)
+	defer ticker.Stop()
+
+	last := l.Status(ctx)
+	if err := send(last); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changes:
+			last = l.Status(ctx)
+		case <-ticker.C:
+			status := l.Status(ctx)
+			if diskStatusChangedSignificantly(last, status) == false {
+				continue
+			}
+			last = status
+		}
+		if err := send(last); err != nil {
+			return err
+		}
+	}
+}
+
+// diskStatusChangedSignificantly returns true if the disk usage
+// moved by more than 1% of the total disk size, or if the estimated
+// byte rate changed by more than 50%.
+func diskStatusChangedSignificantly(last, current *letopb.Status) bool {
+	freeDelta := current.FreeBytes - last.FreeBytes
+	if freeDelta < 0 {
+		freeDelta = -freeDelta
+	}
+	if current.TotalBytes > 0 && 100*freeDelta >= current.TotalBytes {
+		return true
+	}
+
+	if last.BytesPerSecond == 0 {
+		return current.BytesPerSecond != 0
+	}
+	rateDelta := float64(current.BytesPerSecond-last.BytesPerSecond) / float64(last.BytesPerSecond)
+	return rateDelta > 0.5 || rateDelta < -0.5
+}
+
+func (l *Leto) registerStatusWatcher() (int, <-chan struct{}) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	id := l.nextWatcherID
+	l.nextWatcherID += 1
+	// a single slot is enough: consecutive changes are coalesced
+	// into a single notification.
+	l.statusWatchers[id] = make(chan struct{}, 1)
+	return id, l.statusWatchers[id]
+}
+
+func (l *Leto) unregisterStatusWatcher(id int) {
 	l.mx.Lock()
 	defer l.mx.Unlock()
-	return l.lastExperimentLog
+	delete(l.statusWatchers, id)
+}
+
+// notifyStatusChange must be called with l.mx held.
+func (l *Leto) notifyStatusChange() {
+	for _, ch := range l.statusWatchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (l *Leto) LastExperimentLog() (*letopb.ExperimentLog, error) {
+	return l.history.Last()
+}
+
+func (l *Leto) ExperimentLog(id int32) (*letopb.ExperimentLog, error) {
+	return l.history.Get(id)
+}
+
+func (l *Leto) ListExperiments() ([]*letopb.ExperimentLog, error) {
+	return l.history.List()
 }
 
 func endSpan(span trace.Span, err error) {
@@ -189,7 +347,15 @@ func endSpan(span trace.Span, err error) {
 	span.End()
 }
 
-func (l *Leto) Start(ctx context.Context, user *leto.TrackingConfiguration) (err error) {
+func (l *Leto) Start(ctx context.Context, user *leto.TrackingConfiguration) error {
+	_, err := l.StartTracking(ctx, user)
+	return err
+}
+
+// StartTracking starts an experiment on this node and all its slaves,
+// and returns the result on each node. All nodes are first prepared:
+// if any of them could not start the experiment, none is started.
+func (l *Leto) StartTracking(ctx context.Context, user *leto.TrackingConfiguration) (nodes []*letopb.NodeResult, err error) {
 	ctx, span := l.tracer.Start(ctx, "Start")
 	defer func() { endSpan(span, err) }()
 
@@ -198,46 +364,465 @@ func (l *Leto) Start(ctx context.Context, user *leto.TrackingConfiguration) (err
 	return l.start(ctx, user)
 }
 
+// Prepare checks that an experiment could be started on this node,
+// without starting it.
+func (l *Leto) Prepare(ctx context.Context, user *leto.TrackingConfiguration) (err error) {
+	_, span := l.tracer.Start(ctx, "Prepare")
+	defer func() { endSpan(span, err) }()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.isStarted() == true {
+		return errors.New("already started")
+	}
+	env, err := NewExperimentConfiguration(context.Background(), l.leto, l.node, user)
+	if err != nil {
+		return err
+	}
+	return l.prepare(env)
+}
+
+func (l *Leto) prepare(env *TrackingEnvironment) error {
+	if err := l.check(); err != nil {
+		return err
+	}
+	return env.CheckDiskSpace()
+}
+
+// prepareCluster prepares the experiment of env on this node and on
+// all its slaves. The returned error names all failing nodes.
+func (l *Leto) prepareCluster(env *TrackingEnvironment) error {
+	var errs []error
+	if err := l.prepare(env); err != nil {
+		errs = append(errs, fmt.Errorf("could not prepare %s: %w", localHostname(), err))
+	}
+	if env.Node.IsMaster() == false || len(env.Node.Slaves) == 0 {
+		return errors.Join(errs...)
+	}
+
+	nodes, err := leto.NewNodeLister().ListNodes()
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("could not list local nodes: %w", err))...)
+	}
+	for _, name := range env.Node.Slaves {
+		if err := prepareSlave(nodes, env, name); err != nil {
+			errs = append(errs, fmt.Errorf("could not prepare %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func prepareSlave(nodes map[string]leto.Node, env *TrackingEnvironment, name string) error {
+	slave, ok := nodes[name]
+	if ok == false {
+		return errors.New("not found on the network")
+	}
+	request, err := env.SlaveStartRequest(name, env.Config.Loads)
+	if err != nil {
+		return err
+	}
+	return slave.PrepareTracking(request)
+}
+
+// ValidateConfiguration checks that an experiment could be started
+// with user on this node, without starting it. It returns the final
+// configuration and the artemis command line the experiment would
+// use. All errors found are reported at once.
+func (l *Leto) ValidateConfiguration(ctx context.Context, user *leto.TrackingConfiguration) (res *letopb.ValidationResponse, err error) {
+	_, span := l.tracer.Start(ctx, "ValidateConfiguration")
+	defer func() { endSpan(span, err) }()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	env, err := l.validateConfiguration(user)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := env.Config.Yaml()
+	if err != nil {
+		return nil, err
+	}
+	return &letopb.ValidationResponse{
+		YamlConfiguration: string(config),
+		ArtemisCommand:    append([]string{artemisCommandName}, env.TrackingCommandArgs()...),
+		ExperimentDir:     filepath.Base(env.ExperimentDir),
+	}, nil
+}
+
+// validateConfiguration returns the environment an experiment
+// started with user would have, or all errors which would prevent it
+// to start. It must be called with l.mx held.
+func (l *Leto) validateConfiguration(user *leto.TrackingConfiguration) (*TrackingEnvironment, error) {
+	env, err := NewExperimentConfiguration(context.Background(), l.leto, l.node, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.validate(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// validateSchedule checks the configuration of a new schedule.
+func (l *Leto) validateSchedule(user *leto.TrackingConfiguration) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	_, err := l.validateConfiguration(user)
+	return err
+}
+
+func (l *Leto) validate(env *TrackingEnvironment) error {
+	var errs []error
+	if err := env.Config.Detection.Check(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid detection configuration: %w", err))
+	}
+	if env.Node.IsMaster() == true {
+		if err := checkVideoConfiguration(env.ExperimentDir, env.Config); err != nil {
+			errs = append(errs, fmt.Errorf("invalid stream configuration: %w", err))
+		}
+	}
+	if err := l.prepare(env); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func checkVideoConfiguration(dir string, tracking *leto.TrackingConfiguration) error {
+	config, err := newVideoTaskConfig(dir, *tracking.Camera.FPS, tracking.Stream, tracking.Output)
+	if err != nil {
+		return err
+	}
+	return config.Check()
+}
+
+// liveConfigurationFields are the fields which could be changed on a
+// running experiment. They are all in the stream section.
+var liveConfigurationFields = map[string]bool{
+	"stream.host":              true,
+	"stream.bitrate":           true,
+	"stream.bitrate-max-ratio": true,
+	"stream.quality":           true,
+	"stream.tuning":            true,
+}
+
+// restartConfigurationFields are given to artemis on its command
+// line. Changing them on a running experiment restarts the tracking,
+// as a pause immediately resumed: frame IDs continue the ones already
+// written.
+var restartConfigurationFields = map[string]bool{
+	"highlights":         true,
+	"image-renew-period": true,
+	"new-ant-roi":        true,
+	"threads":            true,
+}
+
+// needsRestart returns true if any of changes could only be applied
+// by restarting the tracking.
+func needsRestart(changes []leto.ConfigurationChange) bool {
+	for _, c := range changes {
+		if restartConfigurationFields[c.Path] == true {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLiveChanges returns an error for every change of current which
+// could not be applied on a running experiment.
+func checkLiveChanges(current *leto.TrackingConfiguration, changes []leto.ConfigurationChange) error {
+	var errs []error
+	for _, c := range changes {
+		switch {
+		case c.Path == "stream.host" && (len(*current.Stream.Host) == 0 || c.Value == ""):
+			errs = append(errs, errors.New("'stream.host' could only be changed live from a host to another"))
+		case liveConfigurationFields[c.Path] == true:
+		case restartConfigurationFields[c.Path] == true:
+		case c.Path == "legacy-mode" || strings.HasPrefix(c.Path, "camera.") || strings.HasPrefix(c.Path, "apriltag."):
+			errs = append(errs, fmt.Errorf("'%s' could not be changed live: it would change how frames are tracked within the experiment", c.Path))
+		default:
+			errs = append(errs, fmt.Errorf("'%s' could not be changed live", c.Path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateConfiguration applies the fields set in update to the running
+// experiment, and returns the changes. Affected subtasks are
+// restarted, but not the experiment. Fields given to artemis restart
+// the whole tracking, with frame IDs continuing the ones written. If
+// any change could not be applied live, none is.
+func (l *Leto) UpdateConfiguration(ctx context.Context, update *leto.TrackingConfiguration) (changes []leto.ConfigurationChange, err error) {
+	ctx, span := l.tracer.Start(ctx, "UpdateConfiguration")
+	defer func() { endSpan(span, err) }()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.isStarted() == false {
+		return nil, errors.New("no experiment running")
+	}
+	if len(update.Base) > 0 {
+		return nil, fmt.Errorf("unresolved bases %s", strings.Join(update.Base, ", "))
+	}
+
+	changes = leto.ConfigurationChanges(l.env.Config, update)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	if err := checkLiveChanges(l.env.Config, changes); err != nil {
+		return nil, err
+	}
+	restart := needsRestart(changes) && l.runner != nil
+	if restart == true && l.env.Node.IsMaster() == false {
+		return nil, errors.New("the tracking of slaves is restarted by their master")
+	}
+
+	// merged in a new section, as Merge writes through the pointers
+	// shared with the running configuration.
+	stream := leto.StreamConfiguration{}
+	if err := stream.Merge(&l.env.Config.Stream); err != nil {
+		return nil, err
+	}
+	if err := stream.Merge(&update.Stream); err != nil {
+		return nil, err
+	}
+	config := *l.env.Config
+	config.Stream = stream
+	if l.env.Node.IsMaster() == true {
+		if err := checkVideoConfiguration(l.env.ExperimentDir, &config); err != nil {
+			return nil, fmt.Errorf("invalid stream configuration: %w", err)
+		}
+	}
+
+	logger := l.experimentLogger(ctx, l.env.Config)
+	// a paused experiment uses the new configuration once resumed.
+	if restart == true {
+		logger.Info("restarting tracking to apply configuration changes")
+		if err := l.stopRunner(); err != nil {
+			return nil, err
+		}
+	} else if l.runner != nil {
+		if err := l.runner.Reconfigure(&config, changes); err != nil {
+			return nil, err
+		}
+	}
+	l.env.Config.Stream = stream
+	applyRestartFields(l.env.Config, update, changes)
+
+	for _, c := range changes {
+		logger.WithFields(logrus.Fields{
+			"field":    c.Path,
+			"previous": c.Previous,
+			"value":    c.Value,
+		}).Info("configuration changed")
+	}
+	if err := l.env.recordConfigurationChanges(changes, time.Now()); err != nil {
+		logger.WithError(err).Error("could not record configuration changes")
+	}
+	l.writePersistentFile()
+	if restart == true {
+		// on failure, the experiment ends as if it could not resume.
+		if err := l.runAgain(); err != nil {
+			return nil, fmt.Errorf("could not restart tracking: %w", err)
+		}
+	}
+	l.notifyStatusChange()
+
+	return changes, nil
+}
+
+// applyRestartFields sets in config the restartConfigurationFields
+// of changes, with the values of update. New pointers are used, as
+// they are shared with the previous configurations.
+func applyRestartFields(config, update *leto.TrackingConfiguration, changes []leto.ConfigurationChange) {
+	for _, c := range changes {
+		switch c.Path {
+		case "highlights":
+			highlights := append([]int(nil), *update.Highlights...)
+			config.Highlights = &highlights
+		case "image-renew-period":
+			period := *update.NewAntRenewPeriod
+			config.NewAntRenewPeriod = &period
+		case "new-ant-roi":
+			size := *update.NewAntOutputROISize
+			config.NewAntOutputROISize = &size
+		case "threads":
+			threads := *update.Threads
+			config.Threads = &threads
+		}
+	}
+}
+
+func localHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "localhost"
+	}
+	return hostname
+}
+
 func (l *Leto) experimentLogger(ctx context.Context, config *leto.TrackingConfiguration) *logrus.Entry {
 	return l.logger.WithContext(ctx).WithField("experiment", config.ExperimentName)
 }
 
-func (l *Leto) start(ctx context.Context, user *leto.TrackingConfiguration) (err error) {
+func (l *Leto) start(ctx context.Context, user *leto.TrackingConfiguration) ([]*letopb.NodeResult, error) {
 	if l.isStarted() == true {
-		return errors.New("already started")
+		return nil, errors.New("already started")
 	}
 	var expctx context.Context
 	expctx, l.cancel = context.WithCancel(context.Background())
 	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() == true {
 		expctx = trace.ContextWithSpanContext(expctx, sc)
 	}
-	l.env, err = NewExperimentConfiguration(expctx, l.leto, l.node, user)
+	env, err := NewExperimentConfiguration(expctx, l.leto, l.node, user)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	runner, err := NewExperimentRunner(l.env)
+	if err := l.prepareCluster(env); err != nil {
+		return nil, err
+	}
+	runner, err := NewExperimentRunner(env)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	l.env = env
+	l.runner = runner
+
+	l.experimentLogger(expctx, l.env.Config).Info("starting experiment")
+	l.run(runner)
+
+	report := <-runner.Started()
+	if report.Err != nil {
+		return nil, report.Err
 	}
 
-	logger := l.experimentLogger(expctx, l.env.Config)
+	l.writePersistentFile()
+	l.notifyStatusChange()
+
+	nodes := []*letopb.NodeResult{{
+		Node:          localHostname(),
+		ExperimentDir: filepath.Base(env.ExperimentDir),
+	}}
+	return append(nodes, report.Slaves...), nil
+}
 
+// run runs the experiment of runner until it is paused or ends.
+func (l *Leto) run(runner ExperimentRunner) {
 	go func() {
-		logger.Info("starting experiment")
 		log, err := runner.Run()
-		if err != nil {
-			l.logger.WithError(err).Error("experiment failed")
-		}
 
 		l.mx.Lock()
 		defer l.mx.Unlock()
-		l.lastExperimentLog = log
-		l.env = nil
-		l.removePersistentFile()
-		l.runnerCond.Broadcast()
+		l.runner = nil
+		if err == errExperimentPaused {
+			l.runnerCond.Broadcast()
+			return
+		}
+		l.end(log, err)
 	}()
+}
 
-	l.writePersistentFile()
+// end saves the log of the ended experiment. It must be called with
+// l.mx held.
+func (l *Leto) end(log *letopb.ExperimentLog, err error) {
+	if err != nil {
+		l.logger.WithError(err).Error("experiment failed")
+	}
+
+	if log != nil {
+		if err := l.history.Add(log); err != nil {
+			l.logger.WithError(err).Error("could not save experiment log")
+		}
+	}
+
+	l.env = nil
+	l.runningSchedule = -1
+	l.removePersistentFile()
+	l.runnerCond.Broadcast()
+	l.notifyStatusChange()
+}
+
+// PauseTracking stops artemis and all outputs of the running
+// experiment, but keeps it, so it could be resumed in the same
+// experiment directory. Slaves are stopped and will be restarted on
+// resume.
+func (l *Leto) PauseTracking(ctx context.Context) (err error) {
+	ctx, span := l.tracer.Start(ctx, "PauseTracking")
+	defer func() { endSpan(span, err) }()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.isStarted() == false {
+		return errors.New("no experiment running")
+	}
+	if l.env.Node.IsMaster() == false {
+		return errors.New("experiments of slaves are paused by their master")
+	}
+	if l.runner == nil {
+		return errors.New("already paused")
+	}
+
+	logger := l.experimentLogger(ctx, l.env.Config)
+	logger.Info("pausing experiment")
+	if err := l.stopRunner(); err != nil {
+		return err
+	}
+	l.env.Pause(time.Now())
+	l.notifyStatusChange()
+	return nil
+}
+
+// stopRunner pauses the running runner, and waits for it to
+// terminate. It must be called with l.mx held.
+func (l *Leto) stopRunner() error {
+	l.runner.Pause()
+	for l.runner != nil {
+		l.runnerCond.Wait()
+	}
+	if l.isStarted() == false {
+		return errors.New("experiment ended while pausing")
+	}
+	return nil
+}
+
+// runAgain starts a new runner in the experiment directory, once the
+// previous one is stopped. It must be called with l.mx held.
+func (l *Leto) runAgain() error {
+	l.env.ResetBalancing()
+	runner, err := NewExperimentRunner(l.env)
+	if err != nil {
+		return err
+	}
+	l.runner = runner
+	l.run(runner)
+
+	// on failure, the experiment ends as if it could not start.
+	report := <-runner.Started()
+	return report.Err
+}
+
+// ResumeTracking restarts the paused experiment. New segments
+// continue the ones written before the pause.
+func (l *Leto) ResumeTracking(ctx context.Context) (err error) {
+	ctx, span := l.tracer.Start(ctx, "ResumeTracking")
+	defer func() { endSpan(span, err) }()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.isStarted() == false {
+		return errors.New("no experiment running")
+	}
+	if l.runner != nil {
+		return errors.New("not paused")
+	}
+
+	l.experimentLogger(ctx, l.env.Config).Info("resuming experiment")
+	l.env.Resume(time.Now())
+	if err := l.runAgain(); err != nil {
+		return err
+	}
+	l.notifyStatusChange()
 	return nil
 }
 
@@ -248,6 +833,10 @@ func (l *Leto) Stop(ctx context.Context) (err error) {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
+	return l.stop(ctx)
+}
+
+func (l *Leto) stop(ctx context.Context) error {
 	if l.isStarted() == false {
 		return errors.New("already stopped")
 	}
@@ -255,13 +844,157 @@ func (l *Leto) Stop(ctx context.Context) (err error) {
 	logger.Info("stopping experiment")
 	l.cancel()
 
-	for l.env != nil {
+	for l.runner != nil {
 		l.runnerCond.Wait()
 	}
+	if l.isStarted() == true {
+		// the experiment is paused, no runner tears it down.
+		log, err := l.env.TearDown(nil)
+		l.end(log, err)
+	}
+
+	return nil
+}
+
+func (l *Leto) Schedule(ctx context.Context, config *leto.TrackingConfiguration, start time.Time, end *time.Time) (s *experimentSchedule, err error) {
+	_, span := l.tracer.Start(ctx, "Schedule")
+	defer func() { endSpan(span, err) }()
+
+	return l.scheduler.Add(config, start, end)
+}
+
+func (l *Leto) ListSchedules() []*experimentSchedule {
+	return l.scheduler.List()
+}
+
+// BroadcastClients returns the clients connected to the broadcast
+// stream of the running experiment.
+func (l *Leto) BroadcastClients() ([]*letopb.BroadcastClient, error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.isStarted() == false {
+		return nil, errors.New("no experiment running")
+	}
+	if l.env.Broadcaster == nil {
+		return nil, errors.New("broadcast is only served by the master node")
+	}
+	if l.runner == nil {
+		return nil, errors.New("experiment is paused")
+	}
+	return l.env.Broadcaster.Clients(), nil
+}
+
+func (l *Leto) CancelSchedule(ctx context.Context, id int) (err error) {
+	_, span := l.tracer.Start(ctx, "CancelSchedule")
+	defer func() { endSpan(span, err) }()
+
+	return l.scheduler.Cancel(id)
+}
 
+func (l *Leto) startScheduled(id int, config *leto.TrackingConfiguration) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if _, err := l.start(context.Background(), config); err != nil {
+		return err
+	}
+	l.runningSchedule = id
 	return nil
 }
 
+func (l *Leto) stopScheduled(id int) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.isStarted() == false || l.runningSchedule != id {
+		return fmt.Errorf("scheduled experiment %d is not running", id)
+	}
+	return l.stop(context.Background())
+}
+
+func (l *Leto) loadSchedules() {
+	l.scheduler = newExperimentScheduler(l.schedulesFilePath(),
+		l.validateSchedule,
+		l.startScheduled,
+		l.stopScheduled,
+		l.logger.WithField("path", l.schedulesFilePath()))
+
+	// held while loading, so an ended schedule is only stopped once
+	// the restarted experiment is known to be scheduled.
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if err := l.scheduler.Load(); err != nil {
+		l.logger.WithError(err).Error("could not load scheduled experiments")
+	}
+
+	// the experiment may have been restarted from the persistent
+	// file, we should still be able to stop it.
+	if id, ok := l.scheduler.Started(); ok == true && l.isStarted() == true {
+		l.runningSchedule = id
+	}
+}
+
+func (l *Leto) loadPresets() {
+	l.presets = newPresetStore(l.presetsFilePath())
+	if err := l.presets.Load(); err != nil {
+		l.logger.WithError(err).Error("could not load presets")
+	}
+}
+
+// SavePreset creates or replaces the preset name on this node. Its
+// bases are kept, so changes of base presets are inherited, but they
+// must resolve.
+func (l *Leto) SavePreset(ctx context.Context, name string, config *leto.TrackingConfiguration) (err error) {
+	_, span := l.tracer.Start(ctx, "SavePreset")
+	defer func() { endSpan(span, err) }()
+
+	resolver := l.baseResolver()
+	resolver.Preset = func(n string) (*leto.TrackingConfiguration, error) {
+		if n == name {
+			return config, nil
+		}
+		return l.presets.Get(n)
+	}
+	self := &leto.TrackingConfiguration{Base: leto.ConfigurationBases{leto.PresetBasePrefix + name}}
+	if _, err := resolver.Resolve(self, ""); err != nil {
+		return err
+	}
+
+	return l.presets.Save(name, config)
+}
+
+// DeletePreset removes the preset name from this node.
+func (l *Leto) DeletePreset(ctx context.Context, name string) (err error) {
+	_, span := l.tracer.Start(ctx, "DeletePreset")
+	defer func() { endSpan(span, err) }()
+
+	return l.presets.Delete(name)
+}
+
+func (l *Leto) GetPreset(name string) (*leto.TrackingConfiguration, error) {
+	return l.presets.Get(name)
+}
+
+func (l *Leto) ListPresets() []string {
+	return l.presets.List()
+}
+
+// ResolveConfiguration returns user merged over the preset name, if
+// not empty, with all their bases resolved.
+func (l *Leto) ResolveConfiguration(name string, user *leto.TrackingConfiguration) (*leto.TrackingConfiguration, error) {
+	if len(name) > 0 {
+		withPreset := *user
+		withPreset.Base = append(leto.ConfigurationBases{leto.PresetBasePrefix + name}, user.Base...)
+		user = &withPreset
+	}
+	return l.baseResolver().Resolve(user, "")
+}
+
+// baseResolver resolves preset bases. Base files are only available
+// to clients.
+func (l *Leto) baseResolver() leto.BaseResolver {
+	return leto.BaseResolver{Preset: l.presets.Get}
+}
+
 func (l *Leto) SetMaster(ctx context.Context, hostname string) (err error) {
 	_, span := l.tracer.Start(ctx, "SetMaster")
 	defer func() { endSpan(span, err) }()
@@ -278,6 +1011,7 @@ func (l *Leto) setMaster(hostname string) (err error) {
 	defer func() {
 		if err == nil {
 			l.node.Save()
+			l.notifyStatusChange()
 		}
 	}()
 
@@ -319,6 +1053,7 @@ func (l *Leto) addSlave(hostname string) (err error) {
 	defer func() {
 		if err == nil {
 			l.node.Save()
+			l.notifyStatusChange()
 		}
 	}()
 
@@ -355,6 +1090,7 @@ func (l *Leto) removeSlave(hostname string) (err error) {
 	defer func() {
 		if err == nil {
 			l.node.Save()
+			l.notifyStatusChange()
 		}
 	}()
 
@@ -369,6 +1105,18 @@ func (l *Leto) isStarted() bool {
 	return l.env != nil
 }
 
+func (l *Leto) historyDirPath() string {
+	return filepath.Join(xdg.DataHome, "fort/leto/experiments")
+}
+
+func (l *Leto) presetsFilePath() string {
+	return filepath.Join(xdg.DataHome, "fort/leto/presets.yml")
+}
+
+func (l *Leto) schedulesFilePath() string {
+	return filepath.Join(xdg.DataHome, "fort/leto/scheduled-experiments.yml")
+}
+
 func (l *Leto) persitentFilePath() string {
 	return filepath.Join(xdg.DataHome, "fort/leto/current-experiment.yml")
 }
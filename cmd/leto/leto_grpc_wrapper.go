@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/formicidae-tracker/leto/internal/leto"
 	"github.com/formicidae-tracker/leto/pkg/letopb"
@@ -22,23 +24,70 @@ type LetoGRPCWrapper struct {
 	letopb.UnimplementedLetoServer
 	leto   *Leto
 	logger *logrus.Entry
+	// shutdown is done once the server is stopping. Streams must
+	// return, as GracefulStop waits for them.
+	shutdown context.Context
 }
 
-func (l *LetoGRPCWrapper) StartTracking(ctx context.Context, request *letopb.StartRequest) (*letopb.Empty, error) {
+// parseStartRequest returns the configuration of request, merged
+// over its preset if any, with all bases resolved.
+func (l *LetoGRPCWrapper) parseStartRequest(request *letopb.StartRequest) (*leto.TrackingConfiguration, error) {
 	config, err := leto.ParseConfiguration([]byte(request.YamlConfiguration))
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "could not parse configuration: %s", err)
 	}
+	config, err = l.leto.ResolveConfiguration(request.Preset, config)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not resolve configuration: %s", err)
+	}
+	return config, nil
+}
 
-	l.logger.WithField("experiment", config.ExperimentName).Info("new start request")
+func (l *LetoGRPCWrapper) PrepareTracking(ctx context.Context, request *letopb.StartRequest) (*letopb.Empty, error) {
+	config, err := l.parseStartRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	l.logger.WithField("experiment", config.ExperimentName).Info("new prepare request")
 
-	err = l.leto.Start(ctx, config)
+	err = l.leto.Prepare(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 	return &letopb.Empty{}, nil
 }
 
+func (l *LetoGRPCWrapper) StartTracking(ctx context.Context, request *letopb.StartRequest) (*letopb.StartResponse, error) {
+	config, err := l.parseStartRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	l.logger.WithField("experiment", config.ExperimentName).Info("new start request")
+
+	nodes, err := l.leto.StartTracking(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return &letopb.StartResponse{Nodes: nodes}, nil
+}
+
+func (l *LetoGRPCWrapper) ValidateConfiguration(ctx context.Context, request *letopb.StartRequest) (*letopb.ValidationResponse, error) {
+	config, err := l.parseStartRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	l.logger.WithField("experiment", config.ExperimentName).Info("new validation request")
+
+	res, err := l.leto.ValidateConfiguration(ctx, config)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid configuration: %s", err)
+	}
+	return res, nil
+}
+
 func (l *LetoGRPCWrapper) StopTracking(ctx context.Context, _ *letopb.Empty) (*letopb.Empty, error) {
 	l.logger.Infof("new stop request")
 	err := l.leto.Stop(ctx)
@@ -48,19 +97,213 @@ func (l *LetoGRPCWrapper) StopTracking(ctx context.Context, _ *letopb.Empty) (*l
 	return &letopb.Empty{}, nil
 }
 
+func (l *LetoGRPCWrapper) PauseTracking(ctx context.Context, _ *letopb.Empty) (*letopb.Empty, error) {
+	l.logger.Infof("new pause request")
+	if err := l.leto.PauseTracking(ctx); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "could not pause tracking: %s", err)
+	}
+	return &letopb.Empty{}, nil
+}
+
+func (l *LetoGRPCWrapper) ResumeTracking(ctx context.Context, _ *letopb.Empty) (*letopb.Empty, error) {
+	l.logger.Infof("new resume request")
+	if err := l.leto.ResumeTracking(ctx); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "could not resume tracking: %s", err)
+	}
+	return &letopb.Empty{}, nil
+}
+
+func (l *LetoGRPCWrapper) UpdateConfiguration(ctx context.Context, request *letopb.ConfigurationUpdate) (*letopb.ConfigurationUpdateResponse, error) {
+	config, err := leto.ParseConfiguration([]byte(request.YamlConfiguration))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not parse configuration: %s", err)
+	}
+	config, err = l.leto.ResolveConfiguration("", config)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not resolve configuration: %s", err)
+	}
+
+	l.logger.Info("new configuration update request")
+
+	changes, err := l.leto.UpdateConfiguration(ctx, config)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "could not update configuration: %s", err)
+	}
+	res := &letopb.ConfigurationUpdateResponse{}
+	for _, c := range changes {
+		res.Changes = append(res.Changes, &letopb.ConfigurationChange{
+			Path:     c.Path,
+			Previous: c.Previous,
+			Value:    c.Value,
+		})
+	}
+	return res, nil
+}
+
 func (l *LetoGRPCWrapper) GetStatus(ctx context.Context, _ *letopb.Empty) (*letopb.Status, error) {
 	l.logger.Trace("get status")
 	return l.leto.Status(ctx), nil
 }
 
+func (l *LetoGRPCWrapper) WatchStatus(_ *letopb.Empty, stream letopb.Leto_WatchStatusServer) error {
+	l.logger.Trace("watch status")
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	if l.shutdown != nil {
+		stop := context.AfterFunc(l.shutdown, cancel)
+		defer stop()
+	}
+	return l.leto.WatchStatus(ctx, stream.Send)
+}
+
 func (l *LetoGRPCWrapper) GetLastExperimentLog(context.Context, *letopb.Empty) (*letopb.ExperimentLog, error) {
 	l.logger.Trace("get last experiment log")
 
-	last := l.leto.LastExperimentLog()
-	if last == nil {
-		return nil, status.Error(codes.FailedPrecondition, "no experiment run on node.")
+	return historyResult(l.leto.LastExperimentLog())
+}
+
+func (l *LetoGRPCWrapper) GetExperimentLog(_ context.Context, request *letopb.ExperimentLogRequest) (*letopb.ExperimentLog, error) {
+	l.logger.WithField("id", request.Id).Trace("get experiment log")
+
+	return historyResult(l.leto.ExperimentLog(request.Id))
+}
+
+func (l *LetoGRPCWrapper) ListExperiments(context.Context, *letopb.Empty) (*letopb.ExperimentLogList, error) {
+	l.logger.Trace("list experiments")
+
+	experiments, err := l.leto.ListExperiments()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not list experiments: %s", err)
+	}
+	return &letopb.ExperimentLogList{Experiments: experiments}, nil
+}
+
+func historyResult(log *letopb.ExperimentLog, err error) (*letopb.ExperimentLog, error) {
+	if errors.Is(err, ErrNoExperiment) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s.", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not read experiment log: %s", err)
+	}
+	return log, nil
+}
+
+func (l *LetoGRPCWrapper) ScheduleTracking(ctx context.Context, request *letopb.ScheduleRequest) (*letopb.ScheduledExperiment, error) {
+	config, err := leto.ParseConfiguration([]byte(request.YamlConfiguration))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not parse configuration: %s", err)
+	}
+	config, err = l.leto.ResolveConfiguration("", config)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not resolve configuration: %s", err)
+	}
+	if request.Start == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing schedule start time")
+	}
+	var end *time.Time
+	if request.End != nil {
+		end = new(time.Time)
+		*end = request.End.AsTime()
+	}
+
+	l.logger.WithFields(logrus.Fields{
+		"experiment": config.ExperimentName,
+		"start":      request.Start.AsTime(),
+		"end":        end,
+	}).Info("new schedule request")
+
+	schedule, err := l.leto.Schedule(ctx, config, request.Start.AsTime(), end)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not schedule experiment: %s", err)
+	}
+	return schedule.ToPb()
+}
+
+func (l *LetoGRPCWrapper) ListSchedules(context.Context, *letopb.Empty) (*letopb.ScheduledExperimentList, error) {
+	l.logger.Trace("list schedules")
+
+	res := &letopb.ScheduledExperimentList{}
+	for _, s := range l.leto.ListSchedules() {
+		pb, err := s.ToPb()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not serialize schedule %d: %s", s.ID, err)
+		}
+		res.Schedules = append(res.Schedules, pb)
+	}
+	return res, nil
+}
+
+func (l *LetoGRPCWrapper) CancelSchedule(ctx context.Context, request *letopb.ScheduleCancelRequest) (*letopb.Empty, error) {
+	l.logger.WithField("id", request.Id).Info("new schedule cancel request")
+
+	if err := l.leto.CancelSchedule(ctx, int(request.Id)); err != nil {
+		return nil, status.Errorf(codes.NotFound, "could not cancel schedule: %s", err)
+	}
+	return &letopb.Empty{}, nil
+}
+
+func (l *LetoGRPCWrapper) SavePreset(ctx context.Context, preset *letopb.Preset) (*letopb.Empty, error) {
+	config, err := leto.ParseConfiguration([]byte(preset.YamlConfiguration))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not parse configuration: %s", err)
+	}
+
+	l.logger.WithField("preset", preset.Name).Info("new save preset request")
+
+	if err := l.leto.SavePreset(ctx, preset.Name, config); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not save preset: %s", err)
+	}
+	return &letopb.Empty{}, nil
+}
+
+func (l *LetoGRPCWrapper) ListPresets(context.Context, *letopb.Empty) (*letopb.PresetList, error) {
+	l.logger.Trace("list presets")
+
+	res := &letopb.PresetList{}
+	for _, name := range l.leto.ListPresets() {
+		preset, err := l.getPreset(name)
+		if err != nil {
+			return nil, err
+		}
+		res.Presets = append(res.Presets, preset)
+	}
+	return res, nil
+}
+
+func (l *LetoGRPCWrapper) GetPreset(ctx context.Context, request *letopb.PresetRequest) (*letopb.Preset, error) {
+	l.logger.WithField("preset", request.Name).Trace("get preset")
+	return l.getPreset(request.Name)
+}
+
+func (l *LetoGRPCWrapper) getPreset(name string) (*letopb.Preset, error) {
+	config, err := l.leto.GetPreset(name)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "could not get preset: %s", err)
+	}
+	yaml, err := config.Yaml()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not serialize preset '%s': %s", name, err)
+	}
+	return &letopb.Preset{Name: name, YamlConfiguration: string(yaml)}, nil
+}
+
+func (l *LetoGRPCWrapper) DeletePreset(ctx context.Context, request *letopb.PresetRequest) (*letopb.Empty, error) {
+	l.logger.WithField("preset", request.Name).Info("new delete preset request")
+
+	if err := l.leto.DeletePreset(ctx, request.Name); err != nil {
+		return nil, status.Errorf(codes.NotFound, "could not delete preset: %s", err)
+	}
+	return &letopb.Empty{}, nil
+}
+
+func (l *LetoGRPCWrapper) ListBroadcastClients(context.Context, *letopb.Empty) (*letopb.BroadcastClientList, error) {
+	l.logger.Trace("list broadcast clients")
+
+	clients, err := l.leto.BroadcastClients()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "could not list broadcast clients: %s", err)
 	}
-	return last, nil
+	return &letopb.BroadcastClientList{Clients: clients}, nil
 }
 
 func (l *LetoGRPCWrapper) checkTrackingLink(link *letopb.TrackingLink) (string, error) {
@@ -183,6 +426,7 @@ func (l *LetoGRPCWrapper) Run(config leto.Config) error {
 
 	idleConnections := make(chan struct{})
 	ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
+	l.shutdown = ctx
 
 	go func() {
 		<-ctx.Done()
@@ -8,12 +8,16 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/formicidae-tracker/hermes"
 	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/golang/protobuf/proto"
 	. "gopkg.in/check.v1"
 )
 
@@ -60,6 +64,8 @@ func (s *LetoSuite) TearDownSuite(c *C) {
 }
 
 func (s *LetoSuite) SetUpTest(c *C) {
+	// each test starts with an empty experiment history
+	c.Assert(os.RemoveAll(filepath.Join(xdg.DataHome, "fort/leto/experiments")), IsNil)
 	var err error
 	s.l, err = NewLeto(leto.DefaultConfig)
 	c.Check(err, IsNil)
@@ -98,7 +104,8 @@ func (s *LetoSuite) waitFrames(n int) error {
 }
 
 func (s *LetoSuite) TestTestMode(c *C) {
-	c.Check(s.l.LastExperimentLog(), IsNil)
+	_, err := s.l.LastExperimentLog()
+	c.Check(err, Equals, ErrNoExperiment)
 	conf := &leto.TrackingConfiguration{
 		Camera: leto.CameraConfiguration{
 			FPS: newWithValue(100.0),
@@ -111,8 +118,8 @@ func (s *LetoSuite) TestTestMode(c *C) {
 	c.Check(s.waitFrames(15), IsNil)
 
 	c.Check(s.l.Stop(context.Background()), IsNil)
-	log := s.l.LastExperimentLog()
-	c.Assert(log, Not(IsNil))
+	log, err := s.l.LastExperimentLog()
+	c.Assert(err, IsNil)
 	c.Check(log.HasError, Equals, false)
 
 	entries, err := os.ReadDir(filepath.Join(os.TempDir(), "fort-tests"))
@@ -166,17 +173,25 @@ func (s *LetoSuite) TestE2E(c *C) {
 		},
 	}
 
-	c.Check(s.l.LastExperimentLog(), IsNil)
+	_, err := s.l.LastExperimentLog()
+	c.Check(err, Equals, ErrNoExperiment)
 
 	c.Assert(s.l.Start(context.Background(), conf), IsNil)
 
 	c.Check(s.waitFrames(15), IsNil)
 
 	c.Check(s.l.Stop(context.Background()), IsNil)
-	log := s.l.LastExperimentLog()
-	c.Assert(log, Not(IsNil))
+	log, err := s.l.LastExperimentLog()
+	c.Assert(err, IsNil)
 	c.Check(log.HasError, Equals, false)
 
+	c.Check(log.ConfigurationProvenance["experiment"], Equals, leto.UserLayer)
+	c.Check(log.ConfigurationProvenance["camera.fps"], Equals, leto.UserLayer)
+	c.Check(log.ConfigurationProvenance["load-balancing"], Equals, leto.NodeLayer)
+	c.Check(log.ConfigurationProvenance["threads"], Not(Equals), leto.UserLayer)
+	_, err = os.Stat(filepath.Join(xdg.DataHome, "fort-experiments", log.ExperimentDir, "leto-config-provenance.yaml"))
+	c.Check(err, IsNil)
+
 	// now check we got at least 15 frame saved in the experiment
 	f, err := s.readAllFrames(log.ExperimentDir)
 	c.Check(err, IsNil)
@@ -206,7 +221,397 @@ func (s *LetoSuite) TestArtemisFailure(c *C) {
 
 	c.Assert(s.l.Start(context.Background(), conf), IsNil)
 	time.Sleep(20 * time.Millisecond)
-	log := s.l.LastExperimentLog()
-	c.Assert(log, Not(IsNil))
+	log, err := s.l.LastExperimentLog()
+	c.Assert(err, IsNil)
 	c.Check(log.HasError, Equals, true)
 }
+
+func (s *LetoSuite) TestWatchStatus(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	statuses := make(chan *letopb.Status, 10)
+	done := StartFunc(func() error {
+		return s.l.WatchStatus(ctx, func(status *letopb.Status) error {
+			statuses <- status
+			return nil
+		})
+	})
+	defer func() {
+		cancel()
+		c.Check(<-done, IsNil)
+	}()
+
+	receive := func() *letopb.Status {
+		select {
+		case status := <-statuses:
+			return status
+		case <-time.After(500 * time.Millisecond):
+			return nil
+		}
+	}
+
+	status := receive()
+	c.Assert(status, Not(IsNil))
+	c.Check(status.Experiment, IsNil)
+
+	conf := &leto.TrackingConfiguration{
+		Camera: leto.CameraConfiguration{
+			FPS: newWithValue(100.0),
+		},
+	}
+	c.Assert(s.l.Start(context.Background(), conf), IsNil)
+	status = receive()
+	c.Assert(status, Not(IsNil))
+	c.Check(status.Experiment, Not(IsNil))
+
+	c.Assert(s.l.Stop(context.Background()), IsNil)
+	status = receive()
+	c.Assert(status, Not(IsNil))
+	c.Check(status.Experiment, IsNil)
+}
+
+// watchStatusStream is a Leto_WatchStatusServer whose client never
+// leaves.
+type watchStatusStream struct {
+	letopb.Leto_WatchStatusServer
+}
+
+func (s watchStatusStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s watchStatusStream) Send(*letopb.Status) error {
+	return nil
+}
+
+func (s *LetoSuite) TestWatchStatusReturnsOnShutdown(c *C) {
+	shutdown, cancel := context.WithCancel(context.Background())
+	wrapper := &LetoGRPCWrapper{
+		leto:     s.l,
+		logger:   tm.NewLogger("gRPC"),
+		shutdown: shutdown,
+	}
+	done := StartFunc(func() error {
+		return wrapper.WatchStatus(&letopb.Empty{}, watchStatusStream{})
+	})
+	cancel()
+	select {
+	case err := <-done:
+		c.Check(err, IsNil)
+	case <-time.After(500 * time.Millisecond):
+		c.Fatalf("WatchStatus did not return on shutdown")
+	}
+}
+
+func (s *LetoSuite) TestDiskStatusChangedSignificantly(c *C) {
+	testdata := []struct {
+		Last, Current *letopb.Status
+		Expected      bool
+	}{
+		{
+			Last:     &letopb.Status{TotalBytes: 1000, FreeBytes: 500},
+			Current:  &letopb.Status{TotalBytes: 1000, FreeBytes: 500},
+			Expected: false,
+		},
+		{
+			Last:     &letopb.Status{TotalBytes: 1000, FreeBytes: 500},
+			Current:  &letopb.Status{TotalBytes: 1000, FreeBytes: 495},
+			Expected: false,
+		},
+		{
+			Last:     &letopb.Status{TotalBytes: 1000, FreeBytes: 500},
+			Current:  &letopb.Status{TotalBytes: 1000, FreeBytes: 490},
+			Expected: true,
+		},
+		{
+			Last:     &letopb.Status{TotalBytes: 1000, FreeBytes: 500, BytesPerSecond: 100},
+			Current:  &letopb.Status{TotalBytes: 1000, FreeBytes: 500, BytesPerSecond: 120},
+			Expected: false,
+		},
+		{
+			Last:     &letopb.Status{TotalBytes: 1000, FreeBytes: 500, BytesPerSecond: 100},
+			Current:  &letopb.Status{TotalBytes: 1000, FreeBytes: 500, BytesPerSecond: 10},
+			Expected: true,
+		},
+		{
+			Last:     &letopb.Status{TotalBytes: 1000, FreeBytes: 500},
+			Current:  &letopb.Status{TotalBytes: 1000, FreeBytes: 500, BytesPerSecond: 10},
+			Expected: true,
+		},
+	}
+
+	for i, d := range testdata {
+		c.Check(diskStatusChangedSignificantly(d.Last, d.Current), Equals, d.Expected,
+			Commentf("testdata %d", i))
+	}
+}
+
+func (s *LetoSuite) TestRecoversHermesSegmentsOnStartup(c *C) {
+	dir := filepath.Join(xdg.DataHome, "fort-experiments", "crashed.0000")
+	c.Assert(os.MkdirAll(dir, 0755), IsNil)
+	defer os.RemoveAll(dir)
+
+	b := proto.NewBuffer(nil)
+	c.Assert(b.EncodeMessage(&hermes.Header{Type: hermes.Header_File}), IsNil)
+	c.Assert(b.EncodeMessage(&hermes.FileLine{Readout: &hermes.FrameReadout{FrameID: 1}}), IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "uncompressed-tracking.0000.hermes"), b.Bytes(), 0644), IsNil)
+
+	l, err := NewLeto(leto.DefaultConfig)
+	c.Assert(err, IsNil)
+	c.Check(l, Not(IsNil))
+
+	_, err = os.Stat(filepath.Join(dir, "uncompressed-tracking.0000.hermes"))
+	c.Check(os.IsNotExist(err), Equals, true)
+	report, err := leto.VerifyHermesDirectory(dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, true)
+	c.Check(report.Frames(), Equals, 1)
+}
+
+func (s *LetoSuite) TestPrepare(c *C) {
+	conf := &leto.TrackingConfiguration{
+		Camera: leto.CameraConfiguration{
+			FPS: newWithValue(100.0),
+		},
+	}
+	c.Check(s.l.Prepare(context.Background(), conf), IsNil)
+	c.Check(s.l.isStarted(), Equals, false)
+
+	nodes, err := s.l.StartTracking(context.Background(), conf)
+	c.Assert(err, IsNil)
+	c.Assert(nodes, HasLen, 1)
+	c.Check(nodes[0].ExperimentDir, Matches, "TEST-MODE.*")
+	c.Check(s.l.Prepare(context.Background(), conf), ErrorMatches, "already started")
+}
+
+func (s *LetoSuite) TestNothingStartsIfPrepareFails(c *C) {
+	s.l.leto.DiskLimit = 1 << 62
+	conf := &leto.TrackingConfiguration{
+		Camera: leto.CameraConfiguration{
+			FPS: newWithValue(100.0),
+		},
+	}
+	c.Check(s.l.Prepare(context.Background(), conf), ErrorMatches, "unsufficient disk space: .*")
+	c.Check(s.l.Start(context.Background(), conf), ErrorMatches, "could not prepare .*: unsufficient disk space: .*")
+	c.Check(s.l.isStarted(), Equals, false)
+}
+
+func (s *LetoSuite) TestValidateConfiguration(c *C) {
+	conf := &leto.TrackingConfiguration{
+		ExperimentName: "validated",
+		Camera: leto.CameraConfiguration{
+			FPS: newWithValue(100.0),
+		},
+	}
+	res, err := s.l.ValidateConfiguration(context.Background(), conf)
+	c.Assert(err, IsNil)
+	c.Check(s.l.isStarted(), Equals, false)
+	c.Check(res.ExperimentDir, Equals, "validated.0000")
+	c.Check(res.YamlConfiguration, Matches, "(?s)experiment: validated\n.*fps: 100\n.*")
+	c.Assert(len(res.ArtemisCommand) > 0, Equals, true)
+	c.Check(res.ArtemisCommand[0], Equals, artemisCommandName)
+	c.Check(strings.Join(res.ArtemisCommand, " "), Matches, ".* --camera-fps 100.000000 .*")
+}
+
+func (s *LetoSuite) TestValidateConfigurationReportsAllErrors(c *C) {
+	s.l.leto.DiskLimit = 1 << 62
+	conf := &leto.TrackingConfiguration{
+		Detection: leto.TagDetectionConfiguration{
+			Family: newWithValue("36HARTag"),
+		},
+		Stream: leto.StreamConfiguration{
+			Quality: newWithValue("ultra"),
+		},
+	}
+	_, err := s.l.ValidateConfiguration(context.Background(), conf)
+	c.Check(err, ErrorMatches, `invalid detection configuration: unknown tag family '36HARTag': .*
+invalid stream configuration: unknown quality 'ultra'
+unsufficient disk space: .*`)
+}
+
+func (s *LetoSuite) TestStartsFromPreset(c *C) {
+	preset, err := leto.ParseConfiguration([]byte(`
+experiment: from-preset
+camera:
+  fps: 100
+apriltag:
+  family: 36h11
+`))
+	c.Assert(err, IsNil)
+	c.Assert(s.l.SavePreset(context.Background(), "fast", preset), IsNil)
+	defer s.l.DeletePreset(context.Background(), "fast")
+
+	overrides, err := leto.ParseConfiguration([]byte("apriltag:\n  family: \"\"\n"))
+	c.Assert(err, IsNil)
+	config, err := s.l.ResolveConfiguration("fast", overrides)
+	c.Assert(err, IsNil)
+	c.Check(config.ExperimentName, Equals, "from-preset")
+	c.Check(*config.Camera.FPS, Equals, 100.0)
+	c.Check(*config.Detection.Family, Equals, "")
+
+	nodes, err := s.l.StartTracking(context.Background(), config)
+	c.Assert(err, IsNil)
+	c.Assert(nodes, HasLen, 1)
+	c.Check(nodes[0].ExperimentDir, Matches, "from-preset.*")
+
+	_, err = s.l.ResolveConfiguration("slow", overrides)
+	c.Check(err, ErrorMatches, "could not load base 'preset:slow': unknown preset 'slow'")
+}
+
+func (s *LetoSuite) TestPresetsInheritFromPresets(c *C) {
+	parse := func(content string) *leto.TrackingConfiguration {
+		config, err := leto.ParseConfiguration([]byte(content))
+		c.Assert(err, IsNil)
+		return config
+	}
+	ctx := context.Background()
+	defer s.l.DeletePreset(ctx, "bees")
+	defer s.l.DeletePreset(ctx, "bees-6fps")
+
+	c.Assert(s.l.SavePreset(ctx, "bees", parse("apriltag:\n  family: 36h11\ncamera:\n  fps: 8\n")), IsNil)
+	c.Assert(s.l.SavePreset(ctx, "bees-6fps", parse("base: preset:bees\ncamera:\n  fps: 6\n")), IsNil)
+	c.Check(s.l.SavePreset(ctx, "bees", parse("base: preset:bees-6fps\n")),
+		ErrorMatches, "inheritance cycle: preset:bees -> preset:bees-6fps -> preset:bees")
+	c.Check(s.l.SavePreset(ctx, "ants", parse("base: preset:formica\n")),
+		ErrorMatches, "could not load base 'preset:formica': unknown preset 'formica'")
+
+	config, err := s.l.ResolveConfiguration("bees-6fps", parse("experiment: exp\n"))
+	c.Assert(err, IsNil)
+	c.Check(config.Base, IsNil)
+	c.Check(config.ExperimentName, Equals, "exp")
+	c.Check(*config.Camera.FPS, Equals, 6.0)
+	c.Check(*config.Detection.Family, Equals, "36h11")
+
+	// base presets changes are inherited
+	c.Assert(s.l.SavePreset(ctx, "bees", parse("apriltag:\n  family: 36ARTag\n")), IsNil)
+	config, err = s.l.ResolveConfiguration("bees-6fps", parse("experiment: exp\n"))
+	c.Assert(err, IsNil)
+	c.Check(*config.Detection.Family, Equals, "36ARTag")
+
+	_, err = s.l.ResolveConfiguration("", parse("base: bees.yml\n"))
+	c.Check(err, ErrorMatches, "could not load base 'bees.yml': base files are not available, .*")
+	_, err = s.l.StartTracking(ctx, parse("base: preset:bees\n"))
+	c.Check(err, ErrorMatches, "could not merge tracking configuration: unresolved bases preset:bees")
+}
+
+func (s *LetoSuite) TestUpdateConfiguration(c *C) {
+	parse := func(content string) *leto.TrackingConfiguration {
+		config, err := leto.ParseConfiguration([]byte(content))
+		c.Assert(err, IsNil)
+		return config
+	}
+	ctx := context.Background()
+
+	_, err := s.l.UpdateConfiguration(ctx, parse("stream:\n  bitrate: 4000\n"))
+	c.Check(err, ErrorMatches, "no experiment running")
+
+	c.Assert(s.l.Start(ctx, parse("experiment: test-update\ncamera:\n  fps: 100\n")), IsNil)
+	c.Check(s.waitFrames(5), IsNil)
+
+	changes, err := s.l.UpdateConfiguration(ctx, parse("stream:\n  bitrate: 4000\n  quality: veryfast\n"))
+	c.Assert(err, IsNil)
+	c.Check(changes, DeepEquals, []leto.ConfigurationChange{
+		{Path: "stream.bitrate", Previous: "2000", Value: "4000"},
+		{Path: "stream.quality", Previous: "fast", Value: "veryfast"},
+	})
+	changes, err = s.l.UpdateConfiguration(ctx, parse("stream:\n  bitrate: 4000\n"))
+	c.Check(err, IsNil)
+	c.Check(changes, HasLen, 0)
+
+	_, err = s.l.UpdateConfiguration(ctx, parse("legacy-mode: true\nstream:\n  bitrate: 1000\n  host: olympus.local\n"))
+	c.Check(err, ErrorMatches, `'legacy-mode' could not be changed live: it would change how frames are tracked within the experiment
+'stream.host' could only be changed live from a host to another`)
+	_, err = s.l.UpdateConfiguration(ctx, parse("stream:\n  tuning: noisy\n"))
+	c.Check(err, ErrorMatches, "invalid stream configuration: unknown tune 'noisy'")
+
+	status := s.l.Status(ctx)
+	c.Assert(status.Experiment, Not(IsNil))
+	c.Check(status.Experiment.YamlConfiguration, Matches, `(?s).*bitrate: 4000\n.*`)
+	c.Check(status.Experiment.ConfigurationProvenance["stream.bitrate"], Equals, leto.UpdateLayer)
+
+	dir := filepath.Join(xdg.DataHome, "fort-experiments", status.Experiment.ExperimentDir)
+	changeLog, err := os.ReadFile(filepath.Join(dir, "leto-config-changes.log"))
+	c.Check(err, IsNil)
+	c.Check(string(changeLog), Matches, `\S+ stream.bitrate: 2000 -> 4000
+\S+ stream.quality: fast -> veryfast
+`)
+	final, err := leto.ReadConfiguration(filepath.Join(dir, "leto-final-config.yaml"))
+	c.Assert(err, IsNil)
+	c.Check(*final.Stream.BitRateKB, Equals, 4000)
+
+	// the tracking restarts in the same experiment.
+	changes, err = s.l.UpdateConfiguration(ctx, parse("highlights: [1]\n"))
+	c.Assert(err, IsNil)
+	c.Check(changes, DeepEquals, []leto.ConfigurationChange{
+		{Path: "highlights", Previous: "[]", Value: "[1]"},
+	})
+	status = s.l.Status(ctx)
+	c.Assert(status.Experiment, Not(IsNil))
+	c.Check(status.Experiment.PausedSince, IsNil)
+	c.Check(status.Experiment.ExperimentDir, Equals, filepath.Base(dir))
+
+	// the experiment keeps running.
+	c.Check(s.waitFrames(5), IsNil)
+	c.Check(s.l.Stop(ctx), IsNil)
+	log, err := s.l.LastExperimentLog()
+	c.Assert(err, IsNil)
+	c.Check(log.HasError, Equals, false)
+	c.Check(log.ExperimentDir, Equals, status.Experiment.ExperimentDir)
+
+	report, err := leto.VerifyHermesDirectory(dir)
+	c.Assert(err, IsNil)
+	c.Check(report.ChainErrors, HasLen, 0)
+	c.Assert(report.Segments, HasLen, 2)
+	c.Check(report.Segments[1].FirstFrameID > report.Segments[0].LastFrameID, Equals, true)
+}
+
+func (s *LetoSuite) TestPauseAndResume(c *C) {
+	ctx := context.Background()
+	c.Check(s.l.PauseTracking(ctx), ErrorMatches, "no experiment running")
+
+	conf := &leto.TrackingConfiguration{
+		ExperimentName: "test-pause",
+		Camera: leto.CameraConfiguration{
+			FPS: newWithValue(100.0),
+		},
+	}
+	c.Assert(s.l.Start(ctx, conf), IsNil)
+	c.Check(s.l.ResumeTracking(ctx), ErrorMatches, "not paused")
+	c.Check(s.waitFrames(15), IsNil)
+
+	c.Assert(s.l.PauseTracking(ctx), IsNil)
+	c.Check(s.l.PauseTracking(ctx), ErrorMatches, "already paused")
+	status := s.l.Status(ctx)
+	c.Assert(status.Experiment, Not(IsNil))
+	c.Check(status.Experiment.PausedSince, Not(IsNil))
+	dir := filepath.Join(xdg.DataHome, "fort-experiments", status.Experiment.ExperimentDir)
+
+	c.Assert(s.l.ResumeTracking(ctx), IsNil)
+	c.Check(s.waitFrames(15), IsNil)
+	status = s.l.Status(ctx)
+	c.Assert(status.Experiment, Not(IsNil))
+	c.Check(status.Experiment.PausedSince, IsNil)
+	c.Check(status.Experiment.ExperimentDir, Equals, filepath.Base(dir))
+
+	c.Assert(s.l.PauseTracking(ctx), IsNil)
+	// stopped while paused.
+	c.Check(s.l.Stop(ctx), IsNil)
+
+	log, err := s.l.LastExperimentLog()
+	c.Assert(err, IsNil)
+	c.Check(log.HasError, Equals, false)
+	c.Check(log.ExperimentDir, Equals, filepath.Base(dir))
+	c.Assert(log.Pauses, HasLen, 2)
+	for _, p := range log.Pauses {
+		c.Check(p.End, Not(IsNil))
+		c.Check(p.End.AsTime().Before(p.Start.AsTime()), Equals, false)
+	}
+
+	report, err := leto.VerifyHermesDirectory(dir)
+	c.Assert(err, IsNil)
+	c.Check(report.ChainErrors, HasLen, 0)
+	c.Assert(report.Segments, HasLen, 2)
+	c.Check(report.Segments[0].Next, Equals, "tracking.0001.hermes")
+	c.Check(report.Segments[1].Previous, Equals, "tracking.0000.hermes")
+	// frame IDs continue across the pause.
+	c.Check(report.Segments[1].FirstFrameID > report.Segments[0].LastFrameID, Equals, true)
+}
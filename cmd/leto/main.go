@@ -23,6 +23,10 @@ type Options struct {
 	RPCPort      *int   `long:"rpc-port" description:"Port to use for RPC incoming call"`
 	Devmode      bool   `long:"dev" description:"development mode to bypass some checks"`
 	DiskLimit    int64  `long:"disk-limit" description:"minimum space to leave on disk"`
+
+	Replay      string  `long:"replay" value-name:"DIR" description:"Serves the tracking data of an experiment directory on the broadcast port instead of running the service"`
+	ReplaySpeed float64 `long:"replay-speed" description:"Speed factor of the replay" default:"1.0"`
+	ReplayLoop  bool    `long:"replay-loop" description:"Restarts the replay from the beginning once done"`
 }
 
 func (o *Options) LetoConfig() leto.Config {
@@ -66,5 +70,9 @@ func execute() error {
 	setUpLogger(opts)
 	defer tm.Shutdown(context.Background())
 
+	if len(opts.Replay) > 0 {
+		return replayExperiment(opts.LetoConfig(), opts.Replay, opts.ReplaySpeed, opts.ReplayLoop)
+	}
+
 	return (&LetoGRPCWrapper{}).Run(opts.LetoConfig())
 }
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/formicidae-tracker/leto/internal/leto"
@@ -22,19 +25,30 @@ type masterRunner struct {
 
 	artemisCmd     *exec.Cmd
 	artemisStarted chan struct{}
+	started        chan startReport
 
 	artemisListener   ArtemisListener
 	hermesBroadcaster HermesBroadcaster
 	fileWriter        HermesFileWriter
 	video             VideoTask
 	dispatcher        FrameDispatcher
+	boundaries        *segmentBoundaries
+	sinks             []*configuredSink
+	rebalancer        *workloadRebalancer
+	supervisor        *slaveSupervisor
 	olympus           OlympusTask
 
+	// slavesMx protects slaves restarts against their final stop.
+	slavesMx      sync.Mutex
+	slavesStopped bool
+
 	trackerCtx, otherCtx             context.Context
 	cancelLocalTracker, cancelOthers context.CancelFunc
 
 	killingGrace time.Duration
 
+	paused atomic.Bool
+
 	artemisOut, videoIn *os.File
 
 	subtasks map[string]<-chan error
@@ -60,6 +74,7 @@ func newMasterRunner(env *TrackingEnvironment) (ExperimentRunner, error) {
 		cancelOthers:       cancelOther,
 		logger:             tm.NewLogger("runner").WithContext(env.Context),
 		artemisStarted:     make(chan struct{}),
+		started:            make(chan startReport, 1),
 		killingGrace:       500 * time.Millisecond,
 	}
 	if env.Config.Camera.FPS != nil {
@@ -76,7 +91,7 @@ func newMasterRunner(env *TrackingEnvironment) (ExperimentRunner, error) {
 
 func (r *masterRunner) SetUp() error {
 	var err error
-	r.artemisListener, err = NewArtemisListener(r.otherCtx, r.env.Leto.ArtemisIncomingPort)
+	r.artemisListener, err = NewArtemisListener(r.otherCtx, r.env.Leto.ArtemisIncomingPort, r.env.FrameIDOffset)
 	if err != nil {
 		return err
 	}
@@ -84,19 +99,36 @@ func (r *masterRunner) SetUp() error {
 	r.hermesBroadcaster, err = NewHermesBroadcaster(r.otherCtx,
 		r.env.Leto.HermesBroadcastPort,
 		time.Duration(3.0*float64(time.Second)/(*r.env.Config.Camera.FPS)),
+		r.env.Config.Broadcast,
 	)
 	if err != nil {
 		return err
 	}
+	r.env.Broadcaster = r.hermesBroadcaster
 
-	r.fileWriter, err = NewFrameReadoutWriter(r.otherCtx, r.env.Path("tracking.hermes"))
+	r.boundaries = newSegmentBoundaries(r.otherCtx, *r.env.Config.Output.Period, *r.env.Config.Camera.FPS, r.env.Rotations())
+
+	r.fileWriter, err = NewFrameReadoutWriter(r.otherCtx, filepath.Join(r.env.CurrentExperimentDir(), "tracking.hermes"), r.env.Config.Output, r.boundaries)
 	if err != nil {
 		return err
 	}
 
-	r.dispatcher = NewFrameDispatcher(r.fileWriter.Incoming(), r.hermesBroadcaster.Incoming())
+	r.sinks, err = NewFrameSinks(r.otherCtx, r.env, r.boundaries)
+	if err != nil {
+		return err
+	}
 
-	r.video, err = NewVideoManager(r.otherCtx, r.env.ExperimentDir, *r.env.Config.Camera.FPS, r.env.Config.Stream)
+	outputs := []DispatchOutput{
+		{Name: "file", Channel: r.fileWriter.Incoming(), Lossless: true, Discarded: r.fileWriter.Discarded()},
+		{Name: "broadcast", Channel: r.hermesBroadcaster.Incoming()},
+	}
+	for _, s := range r.sinks {
+		outputs = append(outputs, s.DispatchOutput())
+	}
+	r.dispatcher = NewFrameDispatcher(outputs...)
+	r.env.Dispatcher = r.dispatcher
+
+	r.video, err = NewVideoManager(r.otherCtx, r.env.CurrentExperimentDir(), *r.env.Config.Camera.FPS, r.env.Config.Stream, r.env.Config.Output, r.boundaries, r.env.FrameIDOffset)
 	if err != nil {
 		return err
 	}
@@ -112,11 +144,19 @@ func (r *masterRunner) SetUp() error {
 	}
 	r.artemisCmd.Stdout = r.artemisOut
 
+	if len(r.env.Node.Slaves) > 0 {
+		r.rebalancer = newWorkloadRebalancer(r.otherCtx, r.env.Config.Loads, r.commitLoads, r.restartSlave)
+	}
+
 	r.olympus, err = NewOlympusTask(r.otherCtx, r.env)
 	if err != nil {
 		r.logger.WithError(err).Error("will not register to olympus")
 	}
 
+	if r.rebalancer != nil {
+		r.supervisor = newSlaveSupervisor(r.otherCtx, r.env, r.rebalancer, r.olympus, r.slaveStatus, r.restartSlave)
+	}
+
 	return nil
 }
 
@@ -124,6 +164,13 @@ func (r *masterRunner) Run() (log *letopb.ExperimentLog, err error) {
 	var errs []error
 	defer func() {
 		err = errors.Join(errs...)
+		if err == nil && r.paused.Load() == true {
+			// the environment is kept to resume the experiment, and
+			// the next frame IDs continue the ones written.
+			r.env.FrameIDOffset = r.env.nextFrameIDOffset(r.boundaries.LastFrameID())
+			err = errExperimentPaused
+			return
+		}
 		var terr error
 		log, terr = r.env.TearDown(err)
 		errs = append(errs, terr)
@@ -133,7 +180,12 @@ func (r *masterRunner) Run() (log *letopb.ExperimentLog, err error) {
 	r.startSubtasks()
 	// to avoid start race condition in
 	go func() {
-		<-r.artemisStarted
+		select {
+		case <-r.artemisStarted:
+		case <-r.otherCtx.Done():
+			// artemis was never started
+			return
+		}
 		//wait for either the env.Context or own to be Done
 		<-r.trackerCtx.Done()
 		// if another critical task or env.Context we need to signal
@@ -179,23 +231,45 @@ func (r *masterRunner) startSubtasks() {
 	r.startSubtask(r.dispatcher, "frame-dispatcher")
 	r.startSubtask(r.fileWriter, "writer")
 	r.startSubtask(r.hermesBroadcaster, "broadcaster")
+	for _, s := range r.sinks {
+		r.startSubtask(s, "sink-"+s.name)
+	}
 	r.startSubtaskFunction(func() error {
 		return r.video.Run(r.videoIn)
 	}, "video")
+	r.startSubtaskFunction(func() error {
+		return r.boundaries.Run(r.otherCtx)
+	}, "segment-boundaries")
 
 	// slaves must be started before local tracker !!
-	r.startSlaves()
+	slaves, startErr := r.startSlaves()
+	r.started <- startReport{Slaves: slaves, Err: startErr}
+	if r.rebalancer != nil && startErr == nil {
+		r.startSubtask(r.rebalancer, "rebalancer")
+		r.startSubtask(NewClockWatcher(r.otherCtx, r.env, r.olympus), "clock-watcher")
+		r.startSubtask(r.supervisor, "slave-supervisor")
+	}
 
 	if r.olympus != nil {
 		r.startSubtask(r.olympus, "olympus-registration")
 	}
 
+	if r.env.Rotation != nil {
+		r.startSubtaskFunction(func() error {
+			return r.env.Rotation.Run(r.otherCtx)
+		}, "rotation")
+	}
+
 	r.startSubtaskFunction(func() error {
+		if startErr != nil {
+			// the experiment stops as if the local tracker failed,
+			// which stops all slaves.
+			return startErr
+		}
 		// in order to avoid a race condition with the interruption
 		// signal, we have to process in two step and signal that the
 		// Process is started.
 		if err := r.artemisCmd.Start(); err != nil {
-			close(r.artemisStarted)
 			return err
 		}
 		close(r.artemisStarted)
@@ -203,6 +277,38 @@ func (r *masterRunner) startSubtasks() {
 	}, "local-tracker")
 }
 
+func (r *masterRunner) Started() <-chan startReport {
+	return r.started
+}
+
+// Reconfigure restarts the video encoding on a new segment for any
+// stream change, and reconnects to olympus if its host changed.
+func (r *masterRunner) Reconfigure(config *leto.TrackingConfiguration, changes []leto.ConfigurationChange) error {
+	stream := false
+	for _, c := range changes {
+		if strings.HasPrefix(c.Path, "stream.") == false {
+			continue
+		}
+		stream = true
+		if c.Path != "stream.host" || r.olympus == nil {
+			continue
+		}
+		if err := r.olympus.Reconnect(*config.Stream.Host); err != nil {
+			return fmt.Errorf("could not reconnect to olympus: %w", err)
+		}
+	}
+	if stream == true {
+		r.video.Reconfigure(config.Stream)
+	}
+	return nil
+}
+
+// Pause stops artemis, and therefore all subtasks and slaves.
+func (r *masterRunner) Pause() {
+	r.paused.Store(true)
+	r.cancelLocalTracker()
+}
+
 func (r *masterRunner) startSubtask(t Task, name string) {
 	s := Start(t)
 	r.subtasks[name] = s
@@ -215,7 +321,7 @@ func (r *masterRunner) startSubtaskFunction(f func() error, name string) {
 
 func (r *masterRunner) mergeFrames() func() error {
 	return func() error {
-		return MergeFrameReadout(r.otherCtx, r.env.Balancing, r.artemisListener.Outbound(), r.dispatcher.Incoming())
+		return MergeFrameReadout(r.otherCtx, r.env.Balancing, r.artemisListener.Outbound(), r.dispatcher.Incoming(), r.rebalancer)
 	}
 }
 
@@ -237,6 +343,8 @@ func (r *masterRunner) waitAnyCriticalSubtask() error {
 		name = "video"
 	case err = <-r.subtasks["disk-watcher"]:
 		name = "disk-watcher"
+	case err = <-r.subtasks["slave-supervisor"]:
+		name = "slave-supervisor"
 	}
 
 	if err == nil && name != "local-tracker" {
@@ -311,28 +419,42 @@ func (r *masterRunner) waitAllSubtasks() {
 	wg.Wait()
 }
 
-func (r *masterRunner) startSlaves() {
+// startSlaves starts the experiment on all slaves with frames to
+// process, and returns their results, or the error of the first slave
+// which could not start. Slaves given up before the experiment was
+// paused are not started again.
+func (r *masterRunner) startSlaves() ([]*letopb.NodeResult, error) {
 	if len(r.env.Node.Slaves) == 0 {
-		return
+		return nil, nil
 	}
 	nl := leto.NewNodeLister()
 	nodes, err := nl.ListNodes()
 	if err != nil {
-		r.logger.Printf("could not list local nodes: %s", err)
-		return
+		return nil, fmt.Errorf("could not list local nodes: %w", err)
 	}
 
+	var res []*letopb.NodeResult
 	for _, name := range r.env.Node.Slaves {
-		if err := r.startSlave(nodes, name); err != nil {
-			r.logger.Printf("could not start slave %s: %s", name, err)
+		if isAssigned(r.env.Config.Loads, name) == false {
+			continue
+		}
+		response, err := r.startSlave(nodes, name, r.env.Config.Loads)
+		if err != nil {
+			return nil, fmt.Errorf("could not start slave %s: %w", name, err)
 		}
+		res = append(res, response.Nodes...)
 	}
+	return res, nil
 }
 
 func (r *masterRunner) stopSlaves() {
 	if len(r.env.Node.Slaves) == 0 {
 		return
 	}
+	r.slavesMx.Lock()
+	defer r.slavesMx.Unlock()
+	r.slavesStopped = true
+
 	nl := leto.NewNodeLister()
 	nodes, err := nl.ListNodes()
 	if err != nil {
@@ -347,20 +469,54 @@ func (r *masterRunner) stopSlaves() {
 	}
 }
 
-func (r *masterRunner) startSlave(nodes map[string]leto.Node, name string) error {
+// commitLoads saves the loads of the rebalancer in the experiment
+// configuration, so they are used once the experiment is resumed.
+func (r *masterRunner) commitLoads(loads *leto.LoadBalancing) {
+	r.env.Config.Loads = loads
+}
+
+// restartSlave restarts the slave name with new loads, unless the
+// experiment is stopping.
+func (r *masterRunner) restartSlave(name string, loads *leto.LoadBalancing) error {
+	r.slavesMx.Lock()
+	defer r.slavesMx.Unlock()
+	if r.slavesStopped == true {
+		return errors.New("experiment is stopping")
+	}
+
+	nodes, err := leto.NewNodeLister().ListNodes()
+	if err != nil {
+		return fmt.Errorf("could not list local nodes: %w", err)
+	}
+	if err := r.stopSlave(nodes, name); err != nil {
+		r.logger.WithError(err).WithField("slave", name).Warn("could not stop slave")
+	}
+	_, err = r.startSlave(nodes, name, loads)
+	return err
+}
+
+func (r *masterRunner) slaveStatus(name string) (*letopb.Status, error) {
+	nodes, err := leto.NewNodeLister().ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("could not list local nodes: %w", err)
+	}
 	slave, ok := nodes[name]
 	if ok == false {
-		return errors.New("not found on the network")
+		return nil, errors.New("not found on the network")
 	}
-	slaveConfig := *r.env.Config
-	slaveConfig.Loads.SelfUUID = slaveConfig.Loads.UUIDs[name]
-	asYaml, err := slaveConfig.Yaml()
+	return slave.GetStatus()
+}
+
+func (r *masterRunner) startSlave(nodes map[string]leto.Node, name string, loads *leto.LoadBalancing) (*letopb.StartResponse, error) {
+	slave, ok := nodes[name]
+	if ok == false {
+		return nil, errors.New("not found on the network")
+	}
+	request, err := r.env.SlaveStartRequest(name, loads)
 	if err != nil {
-		return fmt.Errorf("could not serialize config: %s", err)
+		return nil, err
 	}
-	return slave.StartTracking(&letopb.StartRequest{
-		YamlConfiguration: string(asYaml),
-	})
+	return slave.StartTracking(request)
 }
 
 func (r *masterRunner) stopSlave(nodes map[string]leto.Node, name string) error {
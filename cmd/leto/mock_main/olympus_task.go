@@ -46,6 +46,18 @@ func (mr *MockOlympusTaskMockRecorder) Fatal(err interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockOlympusTask)(nil).Fatal), err)
 }
 
+// PushAlarm mocks base method.
+func (m *MockOlympusTask) PushAlarm(arg0 *api.AlarmUpdate) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PushAlarm", arg0)
+}
+
+// PushAlarm indicates an expected call of PushAlarm.
+func (mr *MockOlympusTaskMockRecorder) PushAlarm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushAlarm", reflect.TypeOf((*MockOlympusTask)(nil).PushAlarm), arg0)
+}
+
 // PushDiskStatus mocks base method.
 func (m *MockOlympusTask) PushDiskStatus(arg0 *api.DiskStatus, arg1 *api.AlarmUpdate) {
 	m.ctrl.T.Helper()
@@ -58,6 +70,20 @@ func (mr *MockOlympusTaskMockRecorder) PushDiskStatus(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushDiskStatus", reflect.TypeOf((*MockOlympusTask)(nil).PushDiskStatus), arg0, arg1)
 }
 
+// Reconnect mocks base method.
+func (m *MockOlympusTask) Reconnect(host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconnect", host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconnect indicates an expected call of Reconnect.
+func (mr *MockOlympusTaskMockRecorder) Reconnect(host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconnect", reflect.TypeOf((*MockOlympusTask)(nil).Reconnect), host)
+}
+
 // Run mocks base method.
 func (m *MockOlympusTask) Run() error {
 	m.ctrl.T.Helper()
@@ -12,6 +12,10 @@ import (
 type NodeConfiguration struct {
 	Master string   `yaml:"master"`
 	Slaves []string `yaml:"slaves"`
+	// Weights are the relative number of frames processed by each
+	// node, by hostname, or localhost for this node. Missing nodes
+	// have a weight of 1.
+	Weights map[string]int `yaml:"weights,omitempty"`
 }
 
 func localConfigPath() (string, error) {
@@ -65,6 +69,25 @@ func (c NodeConfiguration) IsMaster() bool {
 	return len(c.Master) == 0
 }
 
+// Weight returns the weight of the node hostname.
+func (c NodeConfiguration) Weight(hostname string) int {
+	if w, ok := c.Weights[hostname]; ok == true {
+		return w
+	}
+	return 1
+}
+
+// CheckWeights returns an error if any weight is not strictly
+// positive.
+func (c NodeConfiguration) CheckWeights() error {
+	for hostname, w := range c.Weights {
+		if w < 1 {
+			return fmt.Errorf("NodeConfiguration: invalid weight %d for %s: it should be at least 1", w, hostname)
+		}
+	}
+	return nil
+}
+
 func (c *NodeConfiguration) AddSlave(hostname string) error {
 	slaves := make(map[string]int, len(c.Slaves))
 	for i, s := range c.Slaves {
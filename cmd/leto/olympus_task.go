@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/formicidae-tracker/olympus/pkg/api"
 	olympuspb "github.com/formicidae-tracker/olympus/pkg/api"
@@ -20,7 +22,11 @@ import (
 type OlympusTask interface {
 	Task
 	PushDiskStatus(*olympuspb.DiskStatus, *olympuspb.AlarmUpdate)
+	PushAlarm(*olympuspb.AlarmUpdate)
 	Fatal(err error)
+	// Reconnect registers the experiment to the olympus server on
+	// host, instead of the current one.
+	Reconnect(host string) error
 }
 
 type statusAndAlarm struct {
@@ -28,8 +34,26 @@ type statusAndAlarm struct {
 	Update *olympuspb.AlarmUpdate
 }
 
+type olympusClient = olympuspb.ClientTask[*olympuspb.TrackingUpStream, *olympuspb.TrackingDownStream]
+
+// An olympusConnection is a client to a single olympus server.
+type olympusConnection struct {
+	client *olympusClient
+	cancel context.CancelFunc
+}
+
 type olympusTask struct {
-	*olympuspb.ClientTask[*olympuspb.TrackingUpStream, *olympuspb.TrackingDownStream]
+	ctx        context.Context
+	hostname   string
+	experiment string
+	since      time.Time
+	port       int
+	options    []grpc.DialOption
+
+	// mx protects current, the connection used for requests.
+	mx            sync.Mutex
+	current       olympusConnection
+	reconnections chan olympusConnection
 
 	incoming chan statusAndAlarm
 	logger   *logrus.Entry
@@ -45,12 +69,6 @@ func NewOlympusTask(ctx context.Context, env *TrackingEnvironment) (OlympusTask,
 		return nil, errors.New("no olympus host in configuration")
 	}
 
-	declaration := &olympuspb.TrackingDeclaration{
-		Hostname:       hostname,
-		StreamServer:   *target,
-		ExperimentName: env.Config.ExperimentName,
-		Since:          timestamppb.New(env.Start),
-	}
 	incoming := make(chan statusAndAlarm, 10)
 
 	var options []grpc.DialOption
@@ -60,30 +78,96 @@ func NewOlympusTask(ctx context.Context, env *TrackingEnvironment) (OlympusTask,
 		)
 	}
 
-	address := fmt.Sprintf("%s:%d", *target, env.Leto.OlympusPort)
-
 	res := &olympusTask{
-		ClientTask: olympuspb.NewTrackingTask(
-			ctx, address, declaration, api.WithDialOptions(options...)),
-		incoming: incoming,
-		logger:   tm.NewLogger("olympus-registration").WithContext(ctx),
+		ctx:           ctx,
+		hostname:      hostname,
+		experiment:    env.Config.ExperimentName,
+		since:         env.Start,
+		port:          env.Leto.OlympusPort,
+		options:       options,
+		reconnections: make(chan olympusConnection, 1),
+		incoming:      incoming,
+		logger:        tm.NewLogger("olympus-registration").WithContext(ctx),
+	}
+	res.current = res.connect(*target)
+
+	return res, nil
+}
+
+// connect creates a client to the olympus server on host. It is only
+// started by Run.
+func (t *olympusTask) connect(host string) olympusConnection {
+	ctx, cancel := context.WithCancel(t.ctx)
+	declaration := &olympuspb.TrackingDeclaration{
+		Hostname:       t.hostname,
+		StreamServer:   host,
+		ExperimentName: t.experiment,
+		Since:          timestamppb.New(t.since),
 	}
+	address := fmt.Sprintf("%s:%d", host, t.port)
+
+	client := olympuspb.NewTrackingTask(
+		ctx, address, declaration, api.WithDialOptions(t.options...))
 
 	go func() {
-		for connection := range res.ClientTask.Confirmations() {
+		for connection := range client.Confirmations() {
 			if connection.Error != nil {
-				res.logger.WithError(connection.Error).Error("connection error")
+				t.logger.WithError(connection.Error).Error("connection error")
 			} else {
-				res.logger.Info("connected")
-				resp := <-res.ClientTask.Request(res.failureAlarm(nil))
+				t.logger.WithField("address", address).Info("connected")
+				resp := <-client.Request(t.failureAlarm(nil))
 				if resp.Error != nil {
-					res.logger.WithError(resp.Error).Error("failure alarm off")
+					t.logger.WithError(resp.Error).Error("failure alarm off")
 				}
 			}
 		}
 	}()
 
-	return res, nil
+	return olympusConnection{client: client, cancel: cancel}
+}
+
+func (t *olympusTask) client() *olympusClient {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	return t.current.client
+}
+
+func (t *olympusTask) Reconnect(host string) error {
+	if len(host) == 0 {
+		return errors.New("no olympus host")
+	}
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	// a reconnection not yet picked by Run is superseded.
+	select {
+	case pending := <-t.reconnections:
+		pending.cancel()
+	default:
+	}
+	t.current = t.connect(host)
+	t.reconnections <- t.current
+	t.logger.WithField("host", host).Info("reconnecting")
+	return nil
+}
+
+// Run runs the current olympus client, until it terminates on its
+// own, or ctx is done.
+func (t *olympusTask) Run() error {
+	t.mx.Lock()
+	current := t.current
+	t.mx.Unlock()
+	for {
+		done := StartFunc(current.client.Run)
+		select {
+		case err := <-done:
+			current.cancel()
+			return err
+		case next := <-t.reconnections:
+			current.cancel()
+			<-done
+			current = next
+		}
+	}
 }
 
 func (t *olympusTask) PushDiskStatus(status *olympuspb.DiskStatus, update *olympuspb.AlarmUpdate) {
@@ -95,10 +179,23 @@ func (t *olympusTask) PushDiskStatus(status *olympuspb.DiskStatus, update *olymp
 		updates = append(updates, update)
 	}
 
-	response := t.ClientTask.Request(&olympuspb.TrackingUpStream{
+	t.push(&olympuspb.TrackingUpStream{
 		DiskStatus: status,
 		Alarms:     updates,
 	})
+}
+
+func (t *olympusTask) PushAlarm(update *olympuspb.AlarmUpdate) {
+	if update == nil {
+		return
+	}
+	t.push(&olympuspb.TrackingUpStream{
+		Alarms: []*olympuspb.AlarmUpdate{update},
+	})
+}
+
+func (t *olympusTask) push(m *olympuspb.TrackingUpStream) {
+	response := t.client().Request(m)
 
 	go func() {
 		res := <-response
@@ -110,11 +207,12 @@ func (t *olympusTask) PushDiskStatus(status *olympuspb.DiskStatus, update *olymp
 
 func (t *olympusTask) Fatal(err error) {
 	if err != nil {
-		resp := <-t.ClientTask.Request(t.failureAlarm(err))
+		client := t.client()
+		resp := <-client.Request(t.failureAlarm(err))
 		if resp.Error != nil {
 			t.logger.WithError(resp.Error).Error("could not log failure to olympus")
 		}
-		t.ClientTask.Fatal(err)
+		client.Fatal(err)
 	}
 }
 
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"gopkg.in/yaml.v2"
+)
+
+var presetNameRx = regexp.MustCompile(`\A[A-Za-z0-9][A-Za-z0-9._-]*\z`)
+
+// A presetStore keeps named tracking configurations of a node. They
+// are persisted on disk in a single file.
+type presetStore struct {
+	mx      sync.Mutex
+	path    string
+	presets map[string]*leto.TrackingConfiguration
+}
+
+func newPresetStore(path string) *presetStore {
+	return &presetStore{
+		path:    path,
+		presets: make(map[string]*leto.TrackingConfiguration),
+	}
+}
+
+// Load reads all persisted presets.
+func (s *presetStore) Load() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	presets := make(map[string]*leto.TrackingConfiguration)
+	if err := yaml.Unmarshal(data, &presets); err != nil {
+		return fmt.Errorf("could not parse '%s': %w", s.path, err)
+	}
+	s.presets = presets
+	return nil
+}
+
+// Save creates or replaces the preset name.
+func (s *presetStore) Save(name string, config *leto.TrackingConfiguration) error {
+	if presetNameRx.MatchString(name) == false {
+		return fmt.Errorf("invalid preset name '%s': it should only contain letters, digits, '.', '_' or '-'", name)
+	}
+	config, err := copyConfiguration(config)
+	if err != nil {
+		return err
+	}
+	// load balancing is always computed when the experiment starts.
+	config.Loads = nil
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.presets[name] = config
+	return s.save()
+}
+
+// Get returns a copy of the preset name.
+func (s *presetStore) Get(name string) (*leto.TrackingConfiguration, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	config, ok := s.presets[name]
+	if ok == false {
+		return nil, fmt.Errorf("unknown preset '%s'", name)
+	}
+	return copyConfiguration(config)
+}
+
+// List returns the names of all presets, in alphabetical order.
+func (s *presetStore) List() []string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	res := make([]string, 0, len(s.presets))
+	for name := range s.presets {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Delete removes the preset name.
+func (s *presetStore) Delete(name string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := s.presets[name]; ok == false {
+		return fmt.Errorf("unknown preset '%s'", name)
+	}
+	delete(s.presets, name)
+	return s.save()
+}
+
+// save must be called with s.mx held.
+func (s *presetStore) save() error {
+	if len(s.presets) == 0 {
+		err := os.Remove(s.path)
+		if err != nil && errors.Is(err, os.ErrNotExist) == false {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(s.presets)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// copyConfiguration returns a deep copy of config.
+func copyConfiguration(config *leto.TrackingConfiguration) (*leto.TrackingConfiguration, error) {
+	data, err := config.Yaml()
+	if err != nil {
+		return nil, err
+	}
+	return leto.ParseConfiguration(data)
+}
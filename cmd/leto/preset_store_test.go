@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	. "gopkg.in/check.v1"
+)
+
+type PresetStoreSuite struct {
+	path  string
+	store *presetStore
+}
+
+var _ = Suite(&PresetStoreSuite{})
+
+func (s *PresetStoreSuite) SetUpTest(c *C) {
+	s.path = filepath.Join(c.MkDir(), "presets.yml")
+	s.store = newPresetStore(s.path)
+	c.Assert(s.store.Load(), IsNil)
+}
+
+func parsePreset(c *C, content string) *leto.TrackingConfiguration {
+	config, err := leto.ParseConfiguration([]byte(content))
+	c.Assert(err, IsNil)
+	return config
+}
+
+func (s *PresetStoreSuite) TestSavesAndReloads(c *C) {
+	c.Check(s.store.Save("bees-36h11-8fps", parsePreset(c, `
+experiment: bees
+camera:
+  fps: 8
+apriltag:
+  family: 36h11
+`)), IsNil)
+	c.Check(s.store.Save("ants", parsePreset(c, "experiment: ants")), IsNil)
+	c.Check(s.store.List(), DeepEquals, []string{"ants", "bees-36h11-8fps"})
+
+	reloaded := newPresetStore(s.path)
+	c.Assert(reloaded.Load(), IsNil)
+	c.Check(reloaded.List(), DeepEquals, []string{"ants", "bees-36h11-8fps"})
+	config, err := reloaded.Get("bees-36h11-8fps")
+	c.Assert(err, IsNil)
+	c.Check(config.ExperimentName, Equals, "bees")
+	c.Check(*config.Camera.FPS, Equals, 8.0)
+	c.Check(*config.Detection.Family, Equals, "36h11")
+	c.Check(config.Detection.Quad.Decimate, IsNil)
+}
+
+func (s *PresetStoreSuite) TestGetReturnsACopy(c *C) {
+	c.Assert(s.store.Save("bees", parsePreset(c, "camera:\n  fps: 8\n")), IsNil)
+	config, err := s.store.Get("bees")
+	c.Assert(err, IsNil)
+	*config.Camera.FPS = 20.0
+
+	config, err = s.store.Get("bees")
+	c.Assert(err, IsNil)
+	c.Check(*config.Camera.FPS, Equals, 8.0)
+}
+
+func (s *PresetStoreSuite) TestDeletes(c *C) {
+	c.Assert(s.store.Save("bees", parsePreset(c, "experiment: bees")), IsNil)
+	c.Check(s.store.Delete("ants"), ErrorMatches, "unknown preset 'ants'")
+	c.Check(s.store.Delete("bees"), IsNil)
+	c.Check(s.store.List(), HasLen, 0)
+	_, err := s.store.Get("bees")
+	c.Check(err, ErrorMatches, "unknown preset 'bees'")
+	_, err = os.Stat(s.path)
+	c.Check(os.IsNotExist(err), Equals, true)
+}
+
+func (s *PresetStoreSuite) TestRejectsInvalidNames(c *C) {
+	for _, name := range []string{"", "../bees", "bees 8fps", "-bees"} {
+		c.Check(s.store.Save(name, parsePreset(c, "experiment: bees")),
+			ErrorMatches, "invalid preset name .*", Commentf("name: '%s'", name))
+	}
+}
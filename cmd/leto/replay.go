@@ -0,0 +1,306 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/formicidae-tracker/hermes"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A hermesReplayer reads the hermes tracking segments of an
+// experiment directory, following their Previous/Next chain, and
+// sends their frame readouts at the pace they were recorded, scaled
+// by speed.
+type hermesReplayer struct {
+	ctx      context.Context
+	segments []string
+	first    string
+	speed    float64
+	loop     bool
+	outgoing chan<- *hermes.FrameReadout
+	logger   *logrus.Entry
+
+	// wall-clock and recorded time of the first frame replayed.
+	start, origin time.Time
+
+	// first and last frames of the current pass, as recorded.
+	firstFrame, lastFrame *hermes.FrameReadout
+	// added to the frames of the current pass, so frame IDs and time
+	// keep increasing when looping.
+	frameIDOffset int64
+	timeOffset    time.Duration
+}
+
+// NewHermesReplayer creates a Task replaying all tracking segments in
+// dir to outgoing, which is closed once done. If loop is set, it
+// restarts from the first segment after the last one, until ctx is
+// cancelled. Each pass continues the frame IDs and time of the
+// previous one.
+func NewHermesReplayer(ctx context.Context, dir string, speed float64, loop bool, outgoing chan<- *hermes.FrameReadout) (Task, error) {
+	if speed <= 0.0 {
+		return nil, fmt.Errorf("invalid replay speed %g: it should be positive", speed)
+	}
+	segments, err := leto.HermesSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no hermes tracking segment in '%s'", dir)
+	}
+	first, err := firstHermesSegment(segments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hermesReplayer{
+		ctx:      ctx,
+		segments: segments,
+		first:    first,
+		speed:    speed,
+		loop:     loop,
+		outgoing: outgoing,
+		logger:   tm.NewLogger("replay").WithContext(ctx),
+	}, nil
+}
+
+// openHermesSegment opens a compressed segment and reads its header.
+func openHermesSegment(filename string) (*os.File, *gzip.Reader, *hermes.Header, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("could not read '%s': %w", filename, err)
+	}
+	header := &hermes.Header{}
+	if ok, err := hermes.ReadDelimitedMessage(gz, header); err != nil || ok == false {
+		gz.Close()
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("could not read header of '%s': %v", filename, err)
+	}
+	return f, gz, header, nil
+}
+
+// firstHermesSegment returns the first segment of the chain, whose
+// header has no previous segment among segments. Segments are sorted
+// by index, the first one is used if none is found.
+func firstHermesSegment(segments []string) (string, error) {
+	names := make(map[string]bool, len(segments))
+	for _, s := range segments {
+		names[filepath.Base(s)] = true
+	}
+	for _, s := range segments {
+		f, gz, header, err := openHermesSegment(s)
+		if err != nil {
+			return "", err
+		}
+		gz.Close()
+		f.Close()
+		if names[header.Previous] == false {
+			return s, nil
+		}
+	}
+	return segments[0], nil
+}
+
+// nextSegment returns the segment to replay after current, whose
+// footer links to next. Without a valid link, as for a truncated
+// segment, the following unreplayed segment by index is used. It
+// returns an empty string once the chain is complete.
+func (r *hermesReplayer) nextSegment(current, next string, replayed map[string]bool) string {
+	if len(next) > 0 {
+		res := filepath.Join(filepath.Dir(current), next)
+		if _, err := os.Stat(res); err == nil && replayed[res] == false {
+			return res
+		}
+		r.logger.WithFields(logrus.Fields{
+			"file": current,
+			"next": next,
+		}).Warn("broken segment chain")
+	}
+	for i, s := range r.segments {
+		if s != current {
+			continue
+		}
+		for _, following := range r.segments[i+1:] {
+			if replayed[following] == false {
+				return following
+			}
+		}
+	}
+	return ""
+}
+
+func (r *hermesReplayer) Run() error {
+	defer close(r.outgoing)
+
+	for {
+		r.firstFrame, r.lastFrame = nil, nil
+		replayed := make(map[string]bool, len(r.segments))
+		for s := r.first; len(s) > 0; {
+			replayed[s] = true
+			next, err := r.replaySegment(s)
+			if err != nil {
+				if errors.Is(err, context.Canceled) == true {
+					return nil
+				}
+				return err
+			}
+			s = r.nextSegment(s, next, replayed)
+		}
+		if r.loop == false {
+			return nil
+		}
+		r.shiftPass()
+		r.logger.WithField("frameIDOffset", r.frameIDOffset).Info("restarting replay")
+	}
+}
+
+// shiftPass offsets the next pass by the span of the current one,
+// plus the mean interval between its frames.
+func (r *hermesReplayer) shiftPass() {
+	if r.firstFrame == nil {
+		return
+	}
+	frames := r.lastFrame.FrameID - r.firstFrame.FrameID
+	r.frameIDOffset += frames + 1
+	if r.firstFrame.Time == nil || r.lastFrame.Time == nil {
+		return
+	}
+	span := r.lastFrame.Time.AsTime().Sub(r.firstFrame.Time.AsTime())
+	r.timeOffset += span
+	if frames > 0 {
+		r.timeOffset += span / time.Duration(frames)
+	}
+}
+
+// replaySegment replays filename, and returns the name of the next
+// segment in its footer, if any.
+func (r *hermesReplayer) replaySegment(filename string) (string, error) {
+	r.logger.WithField("file", filename).Info("replaying segment")
+
+	f, gz, header, err := openHermesSegment(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	defer gz.Close()
+
+	for {
+		line := &hermes.FileLine{}
+		ok, err := hermes.ReadDelimitedMessage(gz, line)
+		if err != nil {
+			// segments of a crashed experiment may be truncated, we
+			// simply replay what is left.
+			r.logger.WithFields(logrus.Fields{
+				"file":  filename,
+				"error": err,
+			}).Warn("truncated segment")
+			return "", nil
+		}
+		if ok == false {
+			continue
+		}
+
+		if line.Readout != nil {
+			// the file writer strips the frame size from each readout.
+			line.Readout.Width = header.Width
+			line.Readout.Height = header.Height
+			if err := r.send(line.Readout); err != nil {
+				return "", err
+			}
+		}
+
+		if line.Footer != nil {
+			return line.Footer.Next, nil
+		}
+	}
+}
+
+func (r *hermesReplayer) send(ro *hermes.FrameReadout) error {
+	recorded := &hermes.FrameReadout{FrameID: ro.FrameID, Time: ro.Time}
+	if r.firstFrame == nil {
+		r.firstFrame = recorded
+	}
+	r.lastFrame = recorded
+
+	ro.FrameID += r.frameIDOffset
+	if ro.Time != nil {
+		t := ro.Time.AsTime().Add(r.timeOffset)
+		ro.Time = timestamppb.New(t)
+		if err := r.waitFor(t); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	case r.outgoing <- ro:
+		return nil
+	}
+}
+
+// waitFor waits until the time t was recorded, relatively to the
+// first frame replayed.
+func (r *hermesReplayer) waitFor(t time.Time) error {
+	if r.start.IsZero() {
+		r.start = time.Now()
+		r.origin = t
+		return nil
+	}
+
+	deadline := r.start.Add(time.Duration(float64(t.Sub(r.origin)) / r.speed))
+	wait := time.Until(deadline)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// replayExperiment serves the tracking segments of dir on the hermes
+// broadcast port, until all segments were replayed or an interrupt
+// signal is received.
+func replayExperiment(config leto.Config, dir string, speed float64, loop bool) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	broadcaster, err := NewHermesBroadcaster(ctx, config.HermesBroadcastPort, 1*time.Second, leto.RecommendedBroadcastConfiguration())
+	if err != nil {
+		return err
+	}
+
+	replayer, err := NewHermesReplayer(ctx, dir, speed, loop, broadcaster.Incoming())
+	if err != nil {
+		return err
+	}
+
+	broadcasterErr := Start(broadcaster)
+	err = replayer.Run()
+	// the server only stops once its context is done.
+	cancel()
+	if berr := <-broadcasterErr; err == nil {
+		err = berr
+	}
+	return err
+}
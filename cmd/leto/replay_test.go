@@ -0,0 +1,147 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/formicidae-tracker/hermes"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	. "gopkg.in/check.v1"
+)
+
+type ReplaySuite struct {
+	dir   string
+	start time.Time
+}
+
+var _ = Suite(&ReplaySuite{})
+
+func (s *ReplaySuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	s.start = time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+}
+
+// segmentName returns the name of the idx-th tracking segment, or an
+// empty string if idx is negative.
+func segmentName(idx int) string {
+	if idx < 0 {
+		return ""
+	}
+	return fmt.Sprintf("tracking.%04d.hermes", idx)
+}
+
+// writeSegment writes the idx-th tracking segment with frames 10ms
+// apart, chained to the previous and next segments, if not negative.
+func (s *ReplaySuite) writeSegment(c *C, idx int, frameIDs []int64, previous, next int) {
+	f, err := os.Create(filepath.Join(s.dir, segmentName(idx)))
+	c.Assert(err, IsNil)
+	defer func() { c.Assert(f.Close(), IsNil) }()
+	gz := gzip.NewWriter(f)
+	defer func() { c.Assert(gz.Close(), IsNil) }()
+
+	write := func(m proto.Message) {
+		b := proto.NewBuffer(nil)
+		c.Assert(b.EncodeMessage(m), IsNil)
+		_, err := gz.Write(b.Bytes())
+		c.Assert(err, IsNil)
+	}
+
+	write(&hermes.Header{
+		Type:     hermes.Header_File,
+		Version:  &hermes.Version{Vmajor: 0, Vminor: 2},
+		Width:    1920,
+		Height:   1080,
+		Previous: segmentName(previous),
+	})
+	for _, id := range frameIDs {
+		t := s.start.Add(time.Duration(id) * 10 * time.Millisecond)
+		write(&hermes.FileLine{Readout: &hermes.FrameReadout{
+			FrameID: id,
+			Time:    &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())},
+		}})
+	}
+	write(&hermes.FileLine{Footer: &hermes.Footer{Next: segmentName(next)}})
+}
+
+func (s *ReplaySuite) TestChecksArguments(c *C) {
+	_, err := NewHermesReplayer(context.Background(), s.dir, 0.0, false, nil)
+	c.Check(err, ErrorMatches, "invalid replay speed 0: it should be positive")
+	_, err = NewHermesReplayer(context.Background(), s.dir, 1.0, false, nil)
+	c.Check(err, ErrorMatches, "no hermes tracking segment in .*")
+}
+
+func (s *ReplaySuite) TestReplaysAllSegmentsAtScaledSpeed(c *C) {
+	s.writeSegment(c, 0, []int64{0, 1, 2, 3, 4}, -1, 1)
+	s.writeSegment(c, 1, []int64{5, 6, 7, 8, 9, 10}, 0, -1)
+
+	outgoing := make(chan *hermes.FrameReadout)
+	replayer, err := NewHermesReplayer(context.Background(), s.dir, 2.0, false, outgoing)
+	c.Assert(err, IsNil)
+
+	start := time.Now()
+	errs := Start(replayer)
+	var frameIDs []int64
+	for ro := range outgoing {
+		c.Check(ro.Width, Equals, int32(1920))
+		c.Check(ro.Height, Equals, int32(1080))
+		frameIDs = append(frameIDs, ro.FrameID)
+	}
+	c.Check(<-errs, IsNil)
+
+	c.Check(frameIDs, DeepEquals, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	// 100ms were recorded between the first and last frame.
+	c.Check(time.Since(start) >= 50*time.Millisecond, Equals, true)
+}
+
+func (s *ReplaySuite) TestLoopsUntilCancelled(c *C) {
+	s.writeSegment(c, 0, []int64{0, 1}, -1, -1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outgoing := make(chan *hermes.FrameReadout)
+	replayer, err := NewHermesReplayer(ctx, s.dir, 10.0, true, outgoing)
+	c.Assert(err, IsNil)
+
+	errs := Start(replayer)
+	var frameIDs []int64
+	var times []time.Time
+	for ro := range outgoing {
+		frameIDs = append(frameIDs, ro.FrameID)
+		times = append(times, ro.Time.AsTime())
+		if len(frameIDs) == 5 {
+			cancel()
+		}
+	}
+	c.Check(<-errs, IsNil)
+	// a last frame may still be sent while cancelling.
+	c.Assert(len(frameIDs) >= 5, Equals, true)
+	// each pass continues the frame IDs and time of the previous one.
+	c.Check(frameIDs[:5], DeepEquals, []int64{0, 1, 2, 3, 4})
+	for i, t := range times[:5] {
+		c.Check(t.Equal(s.start.Add(time.Duration(i)*10*time.Millisecond)), Equals, true, Commentf("frame %d: %s", i, t))
+	}
+}
+
+func (s *ReplaySuite) TestFollowsSegmentChain(c *C) {
+	// segments are not chained in the order of their names.
+	s.writeSegment(c, 0, []int64{3, 4}, 2, -1)
+	s.writeSegment(c, 1, []int64{0, 1}, -1, 2)
+	s.writeSegment(c, 2, []int64{2}, 1, 0)
+
+	outgoing := make(chan *hermes.FrameReadout)
+	replayer, err := NewHermesReplayer(context.Background(), s.dir, 100.0, false, outgoing)
+	c.Assert(err, IsNil)
+
+	errs := Start(replayer)
+	var frameIDs []int64
+	for ro := range outgoing {
+		frameIDs = append(frameIDs, ro.FrameID)
+	}
+	c.Check(<-errs, IsNil)
+	c.Check(frameIDs, DeepEquals, []int64{0, 1, 2, 3, 4})
+}
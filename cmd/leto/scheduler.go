@@ -0,0 +1,357 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v2"
+)
+
+// An experimentSchedule describes an experiment that should be
+// started at a given time, and optionally stopped at a later time.
+type experimentSchedule struct {
+	ID      int                         `yaml:"id"`
+	Start   time.Time                   `yaml:"start"`
+	End     *time.Time                  `yaml:"end,omitempty"`
+	Config  *leto.TrackingConfiguration `yaml:"config"`
+	Started bool                        `yaml:"started"`
+}
+
+// overlaps returns true if both schedules would be running at the
+// same time. A schedule without an end never terminates.
+func (s *experimentSchedule) overlaps(o *experimentSchedule) bool {
+	if s.End != nil && s.End.After(o.Start) == false {
+		return false
+	}
+	if o.End != nil && o.End.After(s.Start) == false {
+		return false
+	}
+	return true
+}
+
+func (s *experimentSchedule) ToPb() (*letopb.ScheduledExperiment, error) {
+	yaml, err := s.Config.Yaml()
+	if err != nil {
+		return nil, err
+	}
+	res := &letopb.ScheduledExperiment{
+		Id:                int32(s.ID),
+		YamlConfiguration: string(yaml),
+		Start:             timestamppb.New(s.Start),
+		Started:           s.Started,
+	}
+	if s.End != nil {
+		res.End = timestamppb.New(*s.End)
+	}
+	return res, nil
+}
+
+// A schedulerClock gives the time to an experimentScheduler and runs
+// its timers.
+type schedulerClock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) schedulerTimer
+}
+
+type schedulerTimer interface {
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) schedulerTimer {
+	return time.AfterFunc(d, f)
+}
+
+// experimentScheduler starts and stops scheduled experiments at the
+// requested time. All pending schedules are persisted on disk, so
+// they could be reloaded after a reboot.
+type experimentScheduler struct {
+	mx        sync.Mutex
+	path      string
+	nextID    int
+	schedules map[int]*experimentSchedule
+	timers    map[int]schedulerTimer
+	clock     schedulerClock
+
+	validate func(config *leto.TrackingConfiguration) error
+	start    func(id int, config *leto.TrackingConfiguration) error
+	stop     func(id int) error
+
+	logger *logrus.Entry
+}
+
+// newExperimentScheduler creates an experimentScheduler persisted in
+// path. validate checks the configuration of new schedules, so errors
+// are reported when they are added rather than when they start.
+func newExperimentScheduler(path string,
+	validate func(*leto.TrackingConfiguration) error,
+	start func(int, *leto.TrackingConfiguration) error,
+	stop func(int) error,
+	logger *logrus.Entry) *experimentScheduler {
+	return &experimentScheduler{
+		path:      path,
+		schedules: make(map[int]*experimentSchedule),
+		timers:    make(map[int]schedulerTimer),
+		clock:     systemClock{},
+		validate:  validate,
+		start:     start,
+		stop:      stop,
+		logger:    logger,
+	}
+}
+
+// Load reads all persisted schedules and arms them. Schedules that
+// ended before they could start are discarded. Started schedules
+// which ended are stopped right away, as their experiment may have
+// been restarted after a reboot.
+func (s *experimentScheduler) Load() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var schedules []*experimentSchedule
+	if err := yaml.Unmarshal(data, &schedules); err != nil {
+		return fmt.Errorf("could not parse '%s': %w", s.path, err)
+	}
+
+	now := s.clock.Now()
+	for _, sched := range schedules {
+		if sched.ID >= s.nextID {
+			s.nextID = sched.ID + 1
+		}
+		if sched.Started == false && sched.End != nil && sched.End.Before(now) == true {
+			s.logger.WithFields(logrus.Fields{
+				"id":  sched.ID,
+				"end": *sched.End,
+			}).Warn("discarding schedule that ended while leto was not running")
+			continue
+		}
+		if sched.Started == false && sched.Start.Before(now) == true {
+			s.logger.WithFields(logrus.Fields{
+				"id":    sched.ID,
+				"start": sched.Start,
+			}).Warn("starting schedule late")
+		}
+		s.schedules[sched.ID] = sched
+		s.arm(sched, now)
+	}
+
+	return s.save()
+}
+
+// Add registers a new schedule. It returns an error if the
+// configuration is invalid, or if the schedule ends before it starts,
+// is already over, or overlaps with another schedule.
+func (s *experimentScheduler) Add(config *leto.TrackingConfiguration, start time.Time, end *time.Time) (*experimentSchedule, error) {
+	if s.validate != nil {
+		if err := s.validate(config); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	now := s.clock.Now()
+	if end != nil {
+		if end.After(start) == false {
+			return nil, fmt.Errorf("schedule end (%s) is not after its start (%s)", end, start)
+		}
+		if end.After(now) == false {
+			return nil, fmt.Errorf("schedule end (%s) is in the past", end)
+		}
+	}
+
+	sched := &experimentSchedule{
+		Start:  start,
+		End:    end,
+		Config: config,
+	}
+
+	for _, other := range s.schedules {
+		if sched.overlaps(other) == true {
+			return nil, fmt.Errorf("schedule overlaps with schedule %d", other.ID)
+		}
+	}
+
+	sched.ID = s.nextID
+	s.nextID += 1
+	s.schedules[sched.ID] = sched
+	s.arm(sched, now)
+
+	return sched, s.save()
+}
+
+// Cancel removes a schedule. If the scheduled experiment is already
+// started, it will not be stopped automatically.
+func (s *experimentScheduler) Cancel(id int) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if _, ok := s.schedules[id]; ok == false {
+		return fmt.Errorf("unknown schedule %d", id)
+	}
+	s.remove(id)
+	return s.save()
+}
+
+// List returns all pending or started schedules, ordered by start
+// time.
+func (s *experimentScheduler) List() []*experimentSchedule {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.list()
+}
+
+func (s *experimentScheduler) list() []*experimentSchedule {
+	res := make([]*experimentSchedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		res = append(res, sched)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Start.Before(res[j].Start)
+	})
+	return res
+}
+
+// Started returns the ID of the schedule that was started, if any.
+func (s *experimentScheduler) Started() (int, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, sched := range s.schedules {
+		if sched.Started == true {
+			return sched.ID, true
+		}
+	}
+	return -1, false
+}
+
+func (s *experimentScheduler) Close() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, t := range s.timers {
+		t.Stop()
+	}
+	s.timers = make(map[int]schedulerTimer)
+}
+
+// arm must be called with s.mx held.
+func (s *experimentScheduler) arm(sched *experimentSchedule, now time.Time) {
+	id := sched.ID
+	if sched.Started == false {
+		s.timers[id] = s.clock.AfterFunc(sched.Start.Sub(now), func() { s.fireStart(id) })
+		return
+	}
+	if sched.End != nil {
+		s.timers[id] = s.clock.AfterFunc(sched.End.Sub(now), func() { s.fireStop(id) })
+		return
+	}
+	// started and never stops: nothing left to do.
+	s.remove(id)
+}
+
+// remove must be called with s.mx held.
+func (s *experimentScheduler) remove(id int) {
+	if t, ok := s.timers[id]; ok == true {
+		t.Stop()
+	}
+	delete(s.timers, id)
+	delete(s.schedules, id)
+}
+
+func (s *experimentScheduler) fireStart(id int) {
+	s.mx.Lock()
+	sched, ok := s.schedules[id]
+	s.mx.Unlock()
+	if ok == false {
+		// cancelled in the meantime
+		return
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"id":         id,
+		"experiment": sched.Config.ExperimentName,
+	})
+	logger.Info("starting scheduled experiment")
+	err := s.start(id, sched.Config)
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := s.schedules[id]; ok == false {
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Error("could not start scheduled experiment")
+		s.remove(id)
+	} else {
+		sched.Started = true
+		s.arm(sched, s.clock.Now())
+	}
+	if err := s.save(); err != nil {
+		logger.WithError(err).Error("could not save schedules")
+	}
+}
+
+func (s *experimentScheduler) fireStop(id int) {
+	s.mx.Lock()
+	_, ok := s.schedules[id]
+	s.mx.Unlock()
+	if ok == false {
+		return
+	}
+
+	logger := s.logger.WithField("id", id)
+	logger.Info("stopping scheduled experiment")
+	if err := s.stop(id); err != nil {
+		logger.WithError(err).Error("could not stop scheduled experiment")
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.remove(id)
+	if err := s.save(); err != nil {
+		logger.WithError(err).Error("could not save schedules")
+	}
+}
+
+// save must be called with s.mx held.
+func (s *experimentScheduler) save() error {
+	schedules := s.list()
+	if len(schedules) == 0 {
+		err := os.Remove(s.path)
+		if err != nil && errors.Is(err, os.ErrNotExist) == false {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(schedules)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/sirupsen/logrus"
+	. "gopkg.in/check.v1"
+)
+
+// A fakeClock only moves when advanced, and then synchronously runs
+// the timers which are due.
+type fakeClock struct {
+	mx     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock   *fakeClock
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) schedulerTimer {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mx.Lock()
+	defer t.clock.mx.Unlock()
+	active := t.stopped == false
+	t.stopped = true
+	return active
+}
+
+// Advance moves the clock by d, and returns once all due timers ran,
+// including the ones they armed.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mx.Lock()
+	c.now = c.now.Add(d)
+	c.mx.Unlock()
+	for t := c.nextDue(); t != nil; t = c.nextDue() {
+		t.f()
+	}
+}
+
+func (c *fakeClock) nextDue() *fakeTimer {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	var res *fakeTimer
+	for _, t := range c.timers {
+		if t.stopped == true || t.at.After(c.now) == true {
+			continue
+		}
+		if res == nil || t.at.Before(res.at) == true {
+			res = t
+		}
+	}
+	if res != nil {
+		res.stopped = true
+	}
+	return res
+}
+
+type SchedulerSuite struct {
+	path        string
+	started     chan int
+	stopped     chan int
+	startErr    error
+	validateErr error
+	clock       *fakeClock
+	scheduler   *experimentScheduler
+}
+
+var _ = Suite(&SchedulerSuite{})
+
+func (s *SchedulerSuite) newScheduler() *experimentScheduler {
+	res := newExperimentScheduler(s.path,
+		func(config *leto.TrackingConfiguration) error {
+			return s.validateErr
+		},
+		func(id int, config *leto.TrackingConfiguration) error {
+			if s.startErr != nil {
+				return s.startErr
+			}
+			s.started <- id
+			return nil
+		},
+		func(id int) error {
+			s.stopped <- id
+			return nil
+		},
+		logrus.NewEntry(logrus.New()))
+	res.clock = s.clock
+	return res
+}
+
+func (s *SchedulerSuite) SetUpTest(c *C) {
+	s.path = filepath.Join(c.MkDir(), "schedules.yml")
+	s.started = make(chan int, 10)
+	s.stopped = make(chan int, 10)
+	s.startErr = nil
+	s.validateErr = nil
+	s.clock = &fakeClock{now: time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)}
+	s.scheduler = s.newScheduler()
+}
+
+func (s *SchedulerSuite) TearDownTest(c *C) {
+	s.scheduler.Close()
+}
+
+// receiveID returns the ID sent on ch, timers run synchronously so
+// it is already there, or -1.
+func receiveID(ch <-chan int) int {
+	select {
+	case id := <-ch:
+		return id
+	default:
+		return -1
+	}
+}
+
+func (s *SchedulerSuite) TestStartsAndStops(c *C) {
+	now := s.clock.Now()
+	end := now.Add(2 * time.Hour)
+	sched, err := s.scheduler.Add(&leto.TrackingConfiguration{}, now.Add(time.Hour), &end)
+	c.Assert(err, IsNil)
+	c.Check(sched.ID, Equals, 0)
+
+	s.clock.Advance(59 * time.Minute)
+	c.Check(receiveID(s.started), Equals, -1)
+	s.clock.Advance(time.Minute)
+	c.Check(receiveID(s.started), Equals, 0)
+	id, ok := s.scheduler.Started()
+	c.Check(ok, Equals, true)
+	c.Check(id, Equals, 0)
+
+	s.clock.Advance(time.Hour)
+	c.Check(receiveID(s.stopped), Equals, 0)
+	c.Check(s.scheduler.List(), HasLen, 0)
+}
+
+func (s *SchedulerSuite) TestValidation(c *C) {
+	now := s.clock.Now()
+	past := now.Add(-time.Hour)
+	_, err := s.scheduler.Add(&leto.TrackingConfiguration{}, now.Add(-2*time.Hour), &past)
+	c.Check(err, ErrorMatches, "schedule end .* is in the past")
+
+	_, err = s.scheduler.Add(&leto.TrackingConfiguration{}, now.Add(2*time.Hour), &past)
+	c.Check(err, ErrorMatches, "schedule end .* is not after its start .*")
+
+	end := now.Add(3 * time.Hour)
+	_, err = s.scheduler.Add(&leto.TrackingConfiguration{}, now.Add(time.Hour), &end)
+	c.Check(err, IsNil)
+
+	_, err = s.scheduler.Add(&leto.TrackingConfiguration{}, now.Add(2*time.Hour), nil)
+	c.Check(err, ErrorMatches, "schedule overlaps with schedule 0")
+
+	_, err = s.scheduler.Add(&leto.TrackingConfiguration{}, end, nil)
+	c.Check(err, IsNil)
+
+	s.validateErr = errors.New("invalid detection configuration: unknown family")
+	_, err = s.scheduler.Add(&leto.TrackingConfiguration{}, end.Add(time.Hour), nil)
+	c.Check(err, ErrorMatches, "invalid configuration: invalid detection configuration: unknown family")
+	c.Check(s.scheduler.List(), HasLen, 2)
+}
+
+func (s *SchedulerSuite) TestCancel(c *C) {
+	sched, err := s.scheduler.Add(&leto.TrackingConfiguration{}, s.clock.Now().Add(time.Minute), nil)
+	c.Assert(err, IsNil)
+	c.Check(s.scheduler.Cancel(sched.ID), IsNil)
+	c.Check(s.scheduler.Cancel(sched.ID), ErrorMatches, "unknown schedule 0")
+	s.clock.Advance(time.Hour)
+	c.Check(receiveID(s.started), Equals, -1)
+}
+
+func (s *SchedulerSuite) TestStartFailureRemovesSchedule(c *C) {
+	s.startErr = errors.New("already started")
+	_, err := s.scheduler.Add(&leto.TrackingConfiguration{}, s.clock.Now(), nil)
+	c.Assert(err, IsNil)
+	s.clock.Advance(0)
+	c.Check(s.scheduler.List(), HasLen, 0)
+}
+
+func (s *SchedulerSuite) TestStopsStartedScheduleWhichEndedWhileDown(c *C) {
+	now := s.clock.Now()
+	c.Assert(os.WriteFile(s.path, []byte(`
+- id: 0
+  start: 2023-04-01T06:00:00Z
+  end: 2023-04-01T08:00:00Z
+  config: {experiment: ended}
+  started: true
+- id: 1
+  start: 2023-04-01T07:00:00Z
+  end: 2023-04-01T09:00:00Z
+  config: {experiment: never-started}
+  started: false
+`), 0644), IsNil)
+	c.Assert(now.After(time.Date(2023, 4, 1, 9, 0, 0, 0, time.UTC)), Equals, true)
+
+	c.Assert(s.scheduler.Load(), IsNil)
+	schedules := s.scheduler.List()
+	c.Assert(schedules, HasLen, 1)
+	c.Check(schedules[0].ID, Equals, 0)
+
+	s.clock.Advance(0)
+	c.Check(receiveID(s.stopped), Equals, 0)
+	c.Check(receiveID(s.started), Equals, -1)
+	c.Check(s.scheduler.List(), HasLen, 0)
+}
+
+func (s *SchedulerSuite) TestPersistsOnDisk(c *C) {
+	start := s.clock.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+	_, err := s.scheduler.Add(&leto.TrackingConfiguration{
+		ExperimentName: "persisted",
+	}, start, &end)
+	c.Assert(err, IsNil)
+
+	other := s.newScheduler()
+	defer other.Close()
+	c.Assert(other.Load(), IsNil)
+	schedules := other.List()
+	c.Assert(schedules, HasLen, 1)
+	c.Check(schedules[0].Config.ExperimentName, Equals, "persisted")
+	c.Check(schedules[0].Start.Equal(start), Equals, true)
+	c.Check(schedules[0].End.Equal(end), Equals, true)
+
+	sched, err := other.Add(&leto.TrackingConfiguration{}, end, nil)
+	c.Assert(err, IsNil)
+	c.Check(sched.ID, Equals, 1)
+}
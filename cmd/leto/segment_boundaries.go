@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+)
+
+// segmentBoundaries decides the frame IDs at which the hermes and
+// video outputs start a new segment, so their segments cover the
+// same frames. A boundary is issued every period, or as soon as one
+// output requests it (i.e. its segment is full). Each output reports
+// the frames it processes when it checks for a reached boundary, and
+// boundaries are published to all outputs atomically with these
+// reports: a boundary after the last frame of every output is
+// therefore reached by all of them on the same frame, whatever their
+// lag. An experiment rotation is also published as a boundary,
+// carrying the new directory, so all outputs move to it on the same
+// frame.
+type segmentBoundaries struct {
+	mx      sync.Mutex
+	outputs []*segmentOutput
+	// lastFrameID is the last frame processed by any output.
+	lastFrameID int64
+
+	period    time.Duration
+	margin    int64
+	requests  chan struct{}
+	rotations <-chan string
+
+	logger *logrus.Entry
+}
+
+// A segmentBoundary is the first frame of a new segment. If Directory
+// is not empty, the new segment is written in this experiment
+// directory.
+type segmentBoundary struct {
+	FrameID   int64
+	Directory string
+}
+
+// A segmentOutput is the state of an output, protected by the mutex
+// of its segmentBoundaries.
+type segmentOutput struct {
+	// lastFrameID is the last frame processed by the output, or -1.
+	lastFrameID int64
+	// pending are the boundaries the output did not reach yet. It is
+	// never bounded, so no output misses a boundary.
+	pending []segmentBoundary
+}
+
+// newSegmentBoundaries creates a segmentBoundaries. rotations, which
+// may be nil, receives the new directory of each experiment rotation.
+func newSegmentBoundaries(ctx context.Context, period time.Duration, fps float64, rotations <-chan string) *segmentBoundaries {
+	return &segmentBoundaries{
+		lastFrameID: -1,
+		period:      period,
+		// two seconds worth of frames.
+		margin:    int64(math.Max(1.0, math.Ceil(2.0*fps))),
+		requests:  make(chan struct{}, 1),
+		rotations: rotations,
+		logger:    tm.NewLogger("segments").WithContext(ctx),
+	}
+}
+
+// subscribe registers a new output. It must be called before Run().
+func (b *segmentBoundaries) subscribe() *segmentOutput {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	res := &segmentOutput{lastFrameID: -1}
+	b.outputs = append(b.outputs, res)
+	return res
+}
+
+// LastFrameID returns the last frame processed by any output, or -1.
+func (b *segmentBoundaries) LastFrameID() int64 {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return b.lastFrameID
+}
+
+// Request asks for a new boundary as soon as possible.
+func (b *segmentBoundaries) Request() {
+	select {
+	case b.requests <- struct{}{}:
+	default:
+	}
+}
+
+func (b *segmentBoundaries) Run(ctx context.Context) error {
+	ticker := time.NewTicker(b.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case dir, ok := <-b.rotations:
+			if ok == false {
+				b.rotations = nil
+				continue
+			}
+			ticker.Reset(b.period)
+			b.issue(dir)
+			continue
+		case <-ticker.C:
+		case <-b.requests:
+			ticker.Reset(b.period)
+		}
+		b.issue("")
+	}
+}
+
+// nextFrameID returns the frame ID of the next boundary: margin
+// frames after the slowest output, but always after the last frame
+// of the fastest one, which must not have passed it already. It
+// returns false if no output processed any frame yet. It must be
+// called with b.mx held.
+func (b *segmentBoundaries) nextFrameID() (int64, bool) {
+	slowest := int64(-1)
+	for _, o := range b.outputs {
+		if o.lastFrameID < 0 {
+			continue
+		}
+		if slowest < 0 || o.lastFrameID < slowest {
+			slowest = o.lastFrameID
+		}
+	}
+	if slowest < 0 {
+		return 0, false
+	}
+	if slowest+b.margin > b.lastFrameID {
+		return slowest + b.margin, true
+	}
+	return b.lastFrameID + 1, true
+}
+
+// issue publishes a new boundary, moving to dir if not empty.
+func (b *segmentBoundaries) issue(dir string) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	frameID, ok := b.nextFrameID()
+	if ok == false {
+		if len(dir) == 0 {
+			// nothing was written yet.
+			return
+		}
+		// the very first frame goes in the new directory.
+		frameID = 0
+	}
+	b.publishLocked(segmentBoundary{FrameID: frameID, Directory: dir})
+}
+
+func (b *segmentBoundaries) publish(boundary segmentBoundary) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	b.publishLocked(boundary)
+}
+
+func (b *segmentBoundaries) publishLocked(boundary segmentBoundary) {
+	logger := b.logger.WithField("frameID", boundary.FrameID)
+	if len(boundary.Directory) > 0 {
+		logger = logger.WithField("directory", boundary.Directory)
+	}
+	logger.Debug("new segment boundary")
+	for _, o := range b.outputs {
+		o.pending = append(o.pending, boundary)
+	}
+}
+
+// reached records that output processes frameID, and returns the
+// boundaries it reached, if any.
+func (b *segmentBoundaries) reached(output *segmentOutput, frameID int64) (bool, string) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	if frameID > output.lastFrameID {
+		output.lastFrameID = frameID
+	}
+	if frameID > b.lastFrameID {
+		b.lastFrameID = frameID
+	}
+
+	reached, directory := false, ""
+	for len(output.pending) > 0 && output.pending[0].FrameID <= frameID {
+		if len(output.pending[0].Directory) > 0 {
+			directory = output.pending[0].Directory
+		}
+		output.pending = output.pending[1:]
+		reached = true
+	}
+	return reached, directory
+}
+
+// A segmentBoundaryQueue holds, for a single output, the boundaries it
+// did not reach yet.
+type segmentBoundaryQueue struct {
+	boundaries *segmentBoundaries
+	output     *segmentOutput
+}
+
+func newSegmentBoundaryQueue(b *segmentBoundaries) segmentBoundaryQueue {
+	if b == nil {
+		return segmentBoundaryQueue{}
+	}
+	return segmentBoundaryQueue{boundaries: b, output: b.subscribe()}
+}
+
+// Reached reports that the output processes frameID, and returns true
+// if it is at or past the next pending boundary, in which case the
+// frame should start a new segment. It also returns the directory of
+// the new segment if one of the reached boundaries is a rotation, or
+// an empty string. It must be called for every frame of the output.
+func (q *segmentBoundaryQueue) Reached(frameID int64) (bool, string) {
+	if q.boundaries == nil {
+		return false, ""
+	}
+	return q.boundaries.reached(q.output, frameID)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type SegmentBoundariesSuite struct{}
+
+var _ = Suite(&SegmentBoundariesSuite{})
+
+func (s *SegmentBoundariesSuite) TestMarginIsTwoSeconds(c *C) {
+	b := newSegmentBoundaries(context.Background(), time.Hour, 8.0, nil)
+	c.Check(b.margin, Equals, int64(16))
+	b = newSegmentBoundaries(context.Background(), time.Hour, 0.0, nil)
+	c.Check(b.margin, Equals, int64(1))
+}
+
+func (s *SegmentBoundariesSuite) TestLastFrameIDKeepsMaximum(c *C) {
+	b := newSegmentBoundaries(context.Background(), time.Hour, 1.0, nil)
+	first := newSegmentBoundaryQueue(b)
+	second := newSegmentBoundaryQueue(b)
+	c.Check(b.LastFrameID(), Equals, int64(-1))
+	first.Reached(10)
+	second.Reached(4)
+	c.Check(b.LastFrameID(), Equals, int64(10))
+}
+
+// nextBoundary waits for the next boundary pending for q.
+func nextBoundary(c *C, q segmentBoundaryQueue) segmentBoundary {
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		q.boundaries.mx.Lock()
+		pending := q.output.pending
+		q.boundaries.mx.Unlock()
+		if len(pending) > 0 {
+			return pending[0]
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Fatalf("no boundary published")
+	return segmentBoundary{}
+}
+
+func (s *SegmentBoundariesSuite) TestRequestPublishesToAllSubscribers(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := newSegmentBoundaries(ctx, time.Hour, 2.0, nil)
+	first := newSegmentBoundaryQueue(b)
+	second := newSegmentBoundaryQueue(b)
+	go b.Run(ctx)
+
+	first.Reached(42)
+	second.Reached(42)
+	b.Request()
+
+	for _, q := range []segmentBoundaryQueue{first, second} {
+		c.Check(nextBoundary(c, q), Equals, segmentBoundary{FrameID: 46})
+	}
+}
+
+func (s *SegmentBoundariesSuite) TestNoBoundaryIsDropped(c *C) {
+	b := newSegmentBoundaries(context.Background(), time.Hour, 2.0, nil)
+	q := newSegmentBoundaryQueue(b)
+	for i := int64(1); i <= 100; i++ {
+		b.publish(segmentBoundary{FrameID: 10 * i})
+	}
+	b.publish(segmentBoundary{FrameID: 1010, Directory: "rotated"})
+
+	for i := int64(1); i <= 100; i++ {
+		reached, _ := q.Reached(10 * i)
+		c.Check(reached, Equals, true, Commentf("frame %d", 10*i))
+	}
+	reached, dir := q.Reached(1010)
+	c.Check(reached, Equals, true)
+	c.Check(dir, Equals, "rotated")
+}
+
+func (s *SegmentBoundariesSuite) TestLaggingOutputSplitsOnSameFrame(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := newSegmentBoundaries(ctx, time.Hour, 2.0, nil)
+	video := newSegmentBoundaryQueue(b)
+	hermes := newSegmentBoundaryQueue(b)
+	go b.Run(ctx)
+
+	// hermes lags by much more than the margin of 4 frames.
+	for i := int64(0); i <= 100; i++ {
+		video.Reached(i)
+	}
+	for i := int64(0); i <= 10; i++ {
+		hermes.Reached(i)
+	}
+	b.Request()
+	boundary := nextBoundary(c, video)
+	c.Check(boundary.FrameID > 100, Equals, true)
+
+	split := func(q segmentBoundaryQueue, from int64) int64 {
+		for i := from; i < 200; i++ {
+			if reached, _ := q.Reached(i); reached == true {
+				return i
+			}
+		}
+		return -1
+	}
+	c.Check(split(video, 101), Equals, boundary.FrameID)
+	c.Check(split(hermes, 11), Equals, boundary.FrameID)
+}
+
+func (s *SegmentBoundariesSuite) TestNothingPublishedBeforeFirstFrame(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := newSegmentBoundaries(ctx, time.Millisecond, 2.0, nil)
+	q := newSegmentBoundaryQueue(b)
+	go b.Run(ctx)
+
+	time.Sleep(10 * time.Millisecond)
+	reached, _ := q.Reached(1000)
+	c.Check(reached, Equals, false)
+}
+
+func (s *SegmentBoundariesSuite) TestRotationPublishesDirectory(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rotations := make(chan string)
+	b := newSegmentBoundaries(ctx, time.Hour, 2.0, rotations)
+	q := newSegmentBoundaryQueue(b)
+	go b.Run(ctx)
+
+	// before any frame, the first frame goes in the new directory.
+	rotations <- "first"
+	c.Check(nextBoundary(c, q), Equals, segmentBoundary{FrameID: 0, Directory: "first"})
+	reached, dir := q.Reached(42)
+	c.Check(reached, Equals, true)
+	c.Check(dir, Equals, "first")
+
+	rotations <- "second"
+	c.Check(nextBoundary(c, q), Equals, segmentBoundary{FrameID: 46, Directory: "second"})
+}
+
+func (s *SegmentBoundariesSuite) TestQueueReached(c *C) {
+	b := newSegmentBoundaries(context.Background(), time.Hour, 2.0, nil)
+	q := newSegmentBoundaryQueue(b)
+	b.publish(segmentBoundary{FrameID: 10})
+	b.publish(segmentBoundary{FrameID: 20, Directory: "rotated"})
+	b.publish(segmentBoundary{FrameID: 30})
+
+	for _, d := range []struct {
+		FrameID   int64
+		Reached   bool
+		Directory string
+	}{
+		{9, false, ""},
+		{10, true, ""},
+		{11, false, ""},
+		// a missed boundary is still honored once.
+		{25, true, "rotated"},
+		{26, false, ""},
+		{30, true, ""},
+	} {
+		reached, dir := q.Reached(d.FrameID)
+		c.Check(reached, Equals, d.Reached, Commentf("frame %d", d.FrameID))
+		c.Check(dir, Equals, d.Directory, Commentf("frame %d", d.FrameID))
+	}
+
+	var zero segmentBoundaryQueue
+	reached, _ := zero.Reached(0)
+	c.Check(reached, Equals, false)
+}
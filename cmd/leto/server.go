@@ -26,13 +26,18 @@ type Server struct {
 }
 
 func NewServer(ctx context.Context, port int, domain string, grace time.Duration) (*Server, error) {
-	logger := tm.NewLogger(domain).WithContext(ctx)
-
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return nil, err
 	}
-	logger.Printf("started listening on :%d", port)
+	return newServerWithListener(ctx, listener, domain, grace), nil
+}
+
+// newServerWithListener creates a Server accepting connections on
+// any listener (i.e. an unix socket).
+func newServerWithListener(ctx context.Context, listener net.Listener, domain string, grace time.Duration) *Server {
+	logger := tm.NewLogger(domain).WithContext(ctx)
+	logger.Printf("started listening on %s", listener.Addr())
 
 	s := &Server{
 		ctx:      ctx,
@@ -43,11 +48,11 @@ func NewServer(ctx context.Context, port int, domain string, grace time.Duration
 
 	go func() {
 		<-ctx.Done()
-		s.logger.Printf("stop listening on :%d", port)
+		s.logger.Printf("stop listening on %s", listener.Addr())
 		s.gracefulStop(grace)
 	}()
 
-	return s, nil
+	return s
 }
 
 func (s *Server) gracefulStop(grace time.Duration) {
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	olympuspb "github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSlaveRetryBackoff is the maximal delay between two attempts to
+// start a slave.
+const maxSlaveRetryBackoff = time.Minute
+
+// slaveHealth is the supervision state of a slave.
+type slaveHealth struct {
+	failingSince time.Time
+	nextRetry    time.Time
+	backoff      time.Duration
+	givenUp      bool
+	degraded     bool
+}
+
+// A slaveSupervisor periodically polls the status of the slaves of an
+// experiment. Slaves which are not tracking are started again with
+// an exponential backoff, and raise an alarm. Once a slave fails for
+// too long, it is given up: the supervisor either returns an error,
+// or reports it to the rebalancer and continues without it. If no
+// other slave could take over its frames, the supervisor continues
+// degraded: the frames are timeouted, and the slave is still started
+// again until it tracks.
+type slaveSupervisor struct {
+	ctx        context.Context
+	slaves     []string
+	config     leto.SlaveSupervisionConfiguration
+	rebalancer *workloadRebalancer
+	olympus    OlympusTask
+	health     map[string]*slaveHealth
+	logger     *logrus.Entry
+
+	status func(name string) (*letopb.Status, error)
+	start  func(name string, loads *leto.LoadBalancing) error
+}
+
+// newSlaveSupervisor creates a slaveSupervisor for all slaves of
+// env. status should return the status of a slave, and start restart
+// its tracking.
+func newSlaveSupervisor(ctx context.Context,
+	env *TrackingEnvironment,
+	rebalancer *workloadRebalancer,
+	olympus OlympusTask,
+	status func(name string) (*letopb.Status, error),
+	start func(name string, loads *leto.LoadBalancing) error) *slaveSupervisor {
+	res := &slaveSupervisor{
+		ctx:        ctx,
+		slaves:     env.Node.Slaves,
+		config:     env.Config.SlaveSupervision,
+		rebalancer: rebalancer,
+		olympus:    olympus,
+		health:     make(map[string]*slaveHealth),
+		logger:     tm.NewLogger("slave-supervisor").WithContext(ctx),
+		status:     status,
+		start:      start,
+	}
+	for _, name := range res.slaves {
+		res.health[name] = &slaveHealth{}
+	}
+	return res
+}
+
+func (s *slaveSupervisor) Run() error {
+	ticker := time.NewTicker(*s.config.Period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := s.poll(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *slaveSupervisor) poll(now time.Time) error {
+	loads := s.rebalancer.Loads()
+	for _, name := range s.slaves {
+		h := s.health[name]
+		if h.givenUp == true || isAssigned(loads, name) == false {
+			continue
+		}
+		if err := s.check(name, h, loads, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isAssigned returns true if the slave name has frames to process.
+func isAssigned(loads *leto.LoadBalancing, name string) bool {
+	puuid, ok := loads.UUIDs[name]
+	if ok == false {
+		return false
+	}
+	for _, assigned := range loads.Assignements {
+		if assigned == puuid {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *slaveSupervisor) check(name string, h *slaveHealth, loads *leto.LoadBalancing, now time.Time) error {
+	logger := s.logger.WithField("slave", name)
+
+	err := s.checkTracking(name)
+	if err == nil {
+		if h.failingSince.IsZero() == false {
+			logger.WithField("after", now.Sub(h.failingSince)).Info("slave is tracking again")
+			s.pushAlarm(name, olympuspb.AlarmStatus_OFF, olympuspb.AlarmLevel_WARNING, "", now)
+		}
+		*h = slaveHealth{}
+		return nil
+	}
+
+	if h.failingSince.IsZero() == true {
+		logger.WithError(err).Warn("slave is not tracking")
+		h.failingSince = now
+		h.nextRetry = now
+		h.backoff = *s.config.Period
+		s.pushAlarm(name, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_WARNING,
+			fmt.Sprintf("slave %s is not tracking: %s", name, err), now)
+	}
+
+	if h.degraded == false && now.Sub(h.failingSince) >= *s.config.GiveUpAfter {
+		if err := s.giveUp(name, h, loads, err, now); err != nil || h.givenUp == true {
+			return err
+		}
+	}
+
+	if now.Before(h.nextRetry) == true {
+		return nil
+	}
+	logger.WithField("backoff", h.backoff).Info("starting slave again")
+	if err := s.start(name, loads); err != nil {
+		logger.WithError(err).Warn("could not start slave")
+	}
+	h.nextRetry = now.Add(h.backoff)
+	h.backoff = Min(2*h.backoff, maxSlaveRetryBackoff)
+	return nil
+}
+
+// checkTracking returns an error if the slave name is not reachable
+// or not tracking.
+func (s *slaveSupervisor) checkTracking(name string) error {
+	status, err := s.status(name)
+	if err != nil {
+		return err
+	}
+	if status.Experiment == nil {
+		return fmt.Errorf("no experiment running")
+	}
+	return nil
+}
+
+// giveUp either aborts the experiment or continues without the
+// slave. The master never takes over the frames of a dead slave, so
+// if no other slave survives, the slave is not given up but marked as
+// degraded: its frames are timeouted until it tracks again.
+func (s *slaveSupervisor) giveUp(name string, h *slaveHealth, loads *leto.LoadBalancing, err error, now time.Time) error {
+	description := fmt.Sprintf("slave %s is not tracking since %s: %s",
+		name, now.Sub(h.failingSince).Round(time.Second), err)
+
+	if *s.config.OnFailure == leto.SlaveFailureAbort {
+		h.givenUp = true
+		s.pushAlarm(name, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_EMERGENCY, description, now)
+		return fmt.Errorf("giving up %s", description)
+	}
+
+	if _, _, rerr := reassignLoads(loads, loads.UUIDs[name]); rerr != nil {
+		h.degraded = true
+		s.pushAlarm(name, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_EMERGENCY,
+			fmt.Sprintf("%s, its frames are timeouted: %s", description, rerr), now)
+		s.logger.WithField("slave", name).WithError(rerr).Error("continuing degraded, frames of slave are timeouted")
+		return nil
+	}
+
+	h.givenUp = true
+	s.pushAlarm(name, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_EMERGENCY, description, now)
+	s.logger.WithField("slave", name).Error("giving up slave, continuing without it")
+	s.rebalancer.ReportDead(loads.UUIDs[name])
+	return nil
+}
+
+func (s *slaveSupervisor) pushAlarm(name string, status olympuspb.AlarmStatus, level olympuspb.AlarmLevel, description string, now time.Time) {
+	if s.olympus == nil {
+		return
+	}
+	s.olympus.PushAlarm(&olympuspb.AlarmUpdate{
+		Identification: "tracking.slave." + name,
+		Status:         status,
+		Level:          level,
+		Description:    description,
+		Time:           timestamppb.New(now),
+	})
+}
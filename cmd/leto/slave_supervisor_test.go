@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/formicidae-tracker/leto/cmd/leto/mock_main"
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/leto/pkg/letopb"
+	olympuspb "github.com/formicidae-tracker/olympus/pkg/api"
+	"github.com/golang/mock/gomock"
+	. "gopkg.in/check.v1"
+)
+
+type SlaveSupervisorSuite struct {
+	ctrl       *gomock.Controller
+	olympus    *mock_main.MockOlympusTask
+	rebalancer *workloadRebalancer
+	supervisor *slaveSupervisor
+
+	running bool
+	starts  []string
+}
+
+var _ = Suite(&SlaveSupervisorSuite{})
+
+func (s *SlaveSupervisorSuite) SetUpTest(c *C) {
+	s.ctrl = gomock.NewController(c)
+	s.olympus = mock_main.NewMockOlympusTask(s.ctrl)
+	s.running = false
+	s.starts = nil
+
+	loads := &leto.LoadBalancing{
+		SelfUUID:     "m",
+		UUIDs:        map[string]string{"localhost": "m", "a": "a-uuid", "b": "b-uuid"},
+		Assignements: map[int]string{0: "m", 1: "a-uuid", 2: "b-uuid"},
+	}
+	s.rebalancer = newWorkloadRebalancer(context.Background(), loads, nil, nil)
+
+	config := leto.RecommendedTrackingConfiguration()
+	*config.SlaveSupervision.GiveUpAfter = time.Minute
+	env := &TrackingEnvironment{
+		Node:   NodeConfiguration{Slaves: []string{"a", "b"}},
+		Config: &config,
+	}
+	s.supervisor = newSlaveSupervisor(context.Background(), env, s.rebalancer, s.olympus,
+		func(name string) (*letopb.Status, error) {
+			if name == "a" && s.running == false {
+				return nil, errors.New("connection refused")
+			}
+			return &letopb.Status{Experiment: &letopb.ExperimentStatus{}}, nil
+		},
+		func(name string, loads *leto.LoadBalancing) error {
+			s.starts = append(s.starts, name)
+			return errors.New("connection refused")
+		})
+}
+
+func (s *SlaveSupervisorSuite) TearDownTest(c *C) {
+	s.ctrl.Finish()
+}
+
+func (s *SlaveSupervisorSuite) expectAlarm(c *C, status olympuspb.AlarmStatus, level olympuspb.AlarmLevel) {
+	s.olympus.EXPECT().PushAlarm(gomock.Any()).Do(func(update *olympuspb.AlarmUpdate) {
+		c.Check(update.Identification, Equals, "tracking.slave.a")
+		c.Check(update.Status, Equals, status)
+		c.Check(update.Level, Equals, level)
+	})
+}
+
+func (s *SlaveSupervisorSuite) TestRetriesWithBackoff(c *C) {
+	start := time.Now()
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_WARNING)
+	for i := 0; i < 6; i++ {
+		c.Check(s.supervisor.poll(start.Add(time.Duration(i)*5*time.Second)), IsNil)
+	}
+	// retried after 0, 5 and 15 seconds.
+	c.Check(s.starts, DeepEquals, []string{"a", "a", "a"})
+
+	s.running = true
+	s.expectAlarm(c, olympuspb.AlarmStatus_OFF, olympuspb.AlarmLevel_WARNING)
+	c.Check(s.supervisor.poll(start.Add(30*time.Second)), IsNil)
+	c.Check(s.supervisor.poll(start.Add(35*time.Second)), IsNil)
+	c.Check(*s.supervisor.health["a"], Equals, slaveHealth{})
+}
+
+func (s *SlaveSupervisorSuite) TestContinuesWithoutGivenUpSlaves(c *C) {
+	start := time.Now()
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_WARNING)
+	c.Check(s.supervisor.poll(start), IsNil)
+
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_EMERGENCY)
+	c.Check(s.supervisor.poll(start.Add(time.Minute)), IsNil)
+	c.Check(s.rebalancer.dead, HasLen, 1)
+	c.Check(<-s.rebalancer.dead, Equals, "a-uuid")
+
+	// given up slaves are not polled anymore
+	c.Check(s.supervisor.poll(start.Add(2*time.Minute)), IsNil)
+	c.Check(s.starts, DeepEquals, []string{"a"})
+}
+
+func (s *SlaveSupervisorSuite) TestAbortsOnGivenUpSlaves(c *C) {
+	*s.supervisor.config.OnFailure = leto.SlaveFailureAbort
+	start := time.Now()
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_WARNING)
+	c.Check(s.supervisor.poll(start), IsNil)
+
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_EMERGENCY)
+	c.Check(s.supervisor.poll(start.Add(time.Minute)), ErrorMatches,
+		"giving up slave a is not tracking since 1m0s: connection refused")
+	c.Check(s.rebalancer.dead, HasLen, 0)
+}
+
+func (s *SlaveSupervisorSuite) TestContinuesDegradedWithoutSurvivingSlaves(c *C) {
+	s.rebalancer.loads.Assignements = map[int]string{0: "m", 1: "a-uuid"}
+	start := time.Now()
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_WARNING)
+	c.Check(s.supervisor.poll(start), IsNil)
+
+	s.expectAlarm(c, olympuspb.AlarmStatus_ON, olympuspb.AlarmLevel_EMERGENCY)
+	c.Check(s.supervisor.poll(start.Add(time.Minute)), IsNil)
+	c.Check(s.rebalancer.dead, HasLen, 0)
+	c.Check(s.supervisor.health["a"].degraded, Equals, true)
+
+	// the slave is still started again, and the alarm is not raised twice.
+	c.Check(s.supervisor.poll(start.Add(2*time.Minute)), IsNil)
+	c.Check(s.starts, DeepEquals, []string{"a", "a", "a"})
+
+	s.running = true
+	s.expectAlarm(c, olympuspb.AlarmStatus_OFF, olympuspb.AlarmLevel_WARNING)
+	c.Check(s.supervisor.poll(start.Add(3*time.Minute)), IsNil)
+	c.Check(*s.supervisor.health["a"], Equals, slaveHealth{})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/formicidae-tracker/leto/pkg/letopb"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v2"
 )
 
 var artemisCommandName = "artemis"
@@ -38,10 +39,25 @@ type TrackingEnvironment struct {
 	Start         time.Time
 	Context       context.Context
 	Rate          *byteRateEstimator
+	Rotation      *experimentRotation
+	// Provenance records which configuration layer supplied each
+	// field of Config.
+	Provenance leto.ConfigurationProvenance
+	// Pauses are the periods the experiment was paused, the last one
+	// has no end while it is paused.
+	Pauses []*letopb.ExperimentPause
+	// FrameIDOffset is added to the frame IDs of artemis, which
+	// numbers frames from zero each time the tracking is started
+	// again, so they keep increasing within the experiment.
+	FrameIDOffset int64
+	// Broadcaster and Dispatcher are set by the master runner once
+	// set up.
+	Broadcaster HermesBroadcaster
+	Dispatcher  FrameDispatcher
 }
 
 func NewExperimentConfiguration(ctx context.Context, leto leto.Config, node NodeConfiguration, user *leto.TrackingConfiguration) (*TrackingEnvironment, error) {
-	tracking, err := finalizeTracking(user, node)
+	tracking, provenance, err := finalizeTracking(user, node)
 	if err != nil {
 		return nil, err
 	}
@@ -49,11 +65,12 @@ func NewExperimentConfiguration(ctx context.Context, leto leto.Config, node Node
 	balancing := newWorkloadBalance(tracking.Loads, *tracking.Camera.FPS)
 
 	res := &TrackingEnvironment{
-		Node:      node,
-		Config:    tracking,
-		Balancing: balancing,
-		Leto:      leto,
-		Context:   ctx,
+		Node:       node,
+		Config:     tracking,
+		Balancing:  balancing,
+		Leto:       leto,
+		Context:    ctx,
+		Provenance: provenance,
 	}
 
 	res.setUpTestMode()
@@ -61,29 +78,69 @@ func NewExperimentConfiguration(ctx context.Context, leto leto.Config, node Node
 		return nil, err
 	}
 
+	if err := res.setUpRotation(); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
-func finalizeTracking(user *leto.TrackingConfiguration, node NodeConfiguration) (*leto.TrackingConfiguration, error) {
-	tracking := leto.LoadDefaultConfig()
+// finalizeTracking merges user over the default configuration and
+// sets up the node's load balancing. It also returns which layer
+// supplied each field of the final configuration.
+func finalizeTracking(user *leto.TrackingConfiguration, node NodeConfiguration) (*leto.TrackingConfiguration, leto.ConfigurationProvenance, error) {
+	if len(user.Base) > 0 {
+		return nil, nil, fmt.Errorf("could not merge tracking configuration: unresolved bases %s", strings.Join(user.Base, ", "))
+	}
+	tracking, provenance, err := leto.LoadDefaultConfigWithProvenance()
+	if err != nil {
+		return nil, nil, err
+	}
 	if err := tracking.Merge(user); err != nil {
-		return nil, fmt.Errorf("could not merge tracking configuration: %w", err)
+		return nil, nil, fmt.Errorf("could not merge tracking configuration: %w", err)
+	}
+	if node.IsMaster() == true {
+		provenance.Record(leto.UserLayer, user)
+	} else {
+		provenance.Record(leto.MasterLayer, user)
 	}
 
 	if err := setUpLoadBalancing(tracking, node); err != nil {
-		return nil, fmt.Errorf("could not setup load balancing: %w", err)
+		return nil, nil, fmt.Errorf("could not setup load balancing: %w", err)
+	}
+	if node.IsMaster() == true {
+		provenance["load-balancing"] = leto.NodeLayer
 	}
 
 	if err := tracking.CheckAllFieldAreSet(); err != nil {
-		return nil, fmt.Errorf("incomplete tracking configuration: %w", err)
+		return nil, nil, fmt.Errorf("incomplete tracking configuration: %w", err)
+	}
+
+	if err := tracking.Output.Check(); err != nil {
+		return nil, nil, fmt.Errorf("invalid output configuration: %w", err)
+	}
+	if err := tracking.Broadcast.Check(); err != nil {
+		return nil, nil, fmt.Errorf("invalid broadcast configuration: %w", err)
+	}
+	if err := tracking.Synchronization.Check(); err != nil {
+		return nil, nil, fmt.Errorf("invalid synchronization configuration: %w", err)
+	}
+	if err := tracking.SlaveSupervision.Check(); err != nil {
+		return nil, nil, fmt.Errorf("invalid slave supervision configuration: %w", err)
 	}
-	return tracking, nil
+	if err := checkSinks(*tracking.Sinks); err != nil {
+		return nil, nil, fmt.Errorf("invalid sinks configuration: %w", err)
+	}
+	return tracking, provenance, nil
 }
 
 func setUpLoadBalancing(tracking *leto.TrackingConfiguration, node NodeConfiguration) error {
 	if node.IsMaster() == false {
 		return nil
 	}
+	if err := node.CheckWeights(); err != nil {
+		return err
+	}
 	tracking.Loads = generateLoadBalancing(node)
 	if len(node.Slaves) == 0 {
 		return nil
@@ -107,11 +164,37 @@ func generateLoadBalancing(c NodeConfiguration) *leto.LoadBalancing {
 		Assignements: make(map[int]string),
 	}
 	res.UUIDs["localhost"] = res.SelfUUID
-	res.Assignements[0] = res.SelfUUID
-	for i, s := range c.Slaves {
-		uuid := uuid.New().String()
-		res.UUIDs[s] = uuid
-		res.Assignements[i+1] = uuid
+	names := append([]string{"localhost"}, c.Slaves...)
+	for _, s := range c.Slaves {
+		res.UUIDs[s] = uuid.New().String()
+	}
+	for id, idx := range weightedSlots(names, c) {
+		res.Assignements[id] = res.UUIDs[names[idx]]
+	}
+	return res
+}
+
+// weightedSlots returns, for each frame of a stride, the index in
+// names of the node processing it. The stride is the sum of all
+// weights, and the frames of each node are spread as evenly as
+// possible.
+func weightedSlots(names []string, c NodeConfiguration) []int {
+	total := 0
+	for _, n := range names {
+		total += c.Weight(n)
+	}
+	res := make([]int, total)
+	current := make([]int, len(names))
+	for id := range res {
+		best := 0
+		for i, n := range names {
+			current[i] += c.Weight(n)
+			if current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+		res[id] = best
 	}
 	return res
 }
@@ -141,6 +224,11 @@ func newWorkloadBalance(lb *leto.LoadBalancing, FPS float64) *WorkloadBalance {
 		MasterUUID: lb.UUIDs["localhost"],
 		Stride:     len(lb.Assignements),
 		IDsByUUID:  make(map[string][]bool),
+		DeadAfter:  deadAfterTimeouts(FPS, len(lb.Assignements)),
+		Hostnames:  make(map[string]string),
+	}
+	for name, uuid := range lb.UUIDs {
+		wb.Hostnames[uuid] = name
 	}
 
 	for id, uuid := range lb.Assignements {
@@ -157,6 +245,9 @@ func (e *TrackingEnvironment) setUpTestMode() {
 	if e.Config.ExperimentName == "" || e.Config.ExperimentName == "TEST-MODE" {
 		e.TestMode = true
 		e.Config.ExperimentName = "TEST-MODE"
+		if e.Provenance != nil {
+			e.Provenance["experiment"] = leto.NodeLayer
+		}
 	} else {
 		e.TestMode = false
 	}
@@ -176,6 +267,35 @@ func (e *TrackingEnvironment) computeExperimentDir() error {
 	return err
 }
 
+func (e *TrackingEnvironment) setUpRotation() error {
+	if e.Node.IsMaster() == false || len(*e.Config.Rotation.At) == 0 {
+		return nil
+	}
+	basename := filepath.Join(e.experimentDestination(), e.Config.ExperimentName)
+	var err error
+	e.Rotation, err = newExperimentRotation(basename, e.ExperimentDir, e.Config, e.saveLocalConfig)
+	return err
+}
+
+// CurrentExperimentDir returns the directory where the experiment
+// data is currently written. It differs from ExperimentDir once the
+// experiment was rotated.
+func (e *TrackingEnvironment) CurrentExperimentDir() string {
+	if e.Rotation == nil {
+		return e.ExperimentDir
+	}
+	return e.Rotation.Current()
+}
+
+// Rotations returns a channel receiving every new experiment
+// directory, or nil if the experiment is never rotated.
+func (e *TrackingEnvironment) Rotations() <-chan string {
+	if e.Rotation == nil {
+		return nil
+	}
+	return e.Rotation.Subscribe()
+}
+
 func (e *TrackingEnvironment) setDiskLimit(free int64) {
 	if e.Leto.DiskLimit > 0 {
 		e.DiskLimit = e.Leto.DiskLimit
@@ -190,6 +310,9 @@ func (e *TrackingEnvironment) Path(p ...string) string {
 }
 
 func (e *TrackingEnvironment) newAntPath() string {
+	if e.Rotation != nil {
+		return e.Rotation.antLink
+	}
 	return e.Path("ants")
 }
 
@@ -254,7 +377,7 @@ func (e *TrackingEnvironment) TrackingCommandArgs() []string {
 	args = append(args, "--log-output-dir", e.ExperimentDir)
 
 	if len(e.Balancing.IDsByUUID) > 1 {
-		args = append(args, "--frame-stride", fmt.Sprintf("%d", len(e.Balancing.IDsByUUID)))
+		args = append(args, "--frame-stride", fmt.Sprintf("%d", e.Balancing.Stride))
 		ids := []string{}
 		for i, isSet := range e.Balancing.IDsByUUID[e.Config.Loads.SelfUUID] {
 			if isSet == false {
@@ -280,16 +403,19 @@ func (e *TrackingEnvironment) TrackingCommandArgs() []string {
 func (e *TrackingEnvironment) SetUp() (*exec.Cmd, error) {
 	var free int64
 	defer func() {
-		e.Start = time.Now()
-		e.Rate = NewByteRateEstimator(free, e.Start)
-
+		now := time.Now()
+		// a resumed experiment keeps its original start.
+		if e.Start.IsZero() == true {
+			e.Start = now
+		}
+		e.Rate = NewByteRateEstimator(free, now)
 	}()
 
 	if err := e.makeAllDestinationDirs(); err != nil {
 		return nil, err
 	}
 
-	if err := e.saveLocalConfig(); err != nil {
+	if err := e.saveLocalConfig(e.CurrentExperimentDir()); err != nil {
 		return nil, err
 	}
 
@@ -298,30 +424,121 @@ func (e *TrackingEnvironment) SetUp() (*exec.Cmd, error) {
 		return nil, err
 	}
 
+	if err := e.checkFreeSpace(free); err != nil {
+		return nil, err
+	}
+
+	return e.buildArtemisCommand()
+}
+
+func (e *TrackingEnvironment) checkFreeSpace(free int64) error {
 	e.setDiskLimit(free)
 
 	if free < e.DiskLimit {
-		return nil, fmt.Errorf("unsufficient disk space: available: %s minimum: %s",
+		return fmt.Errorf("unsufficient disk space: available: %s minimum: %s",
 			humanize.ByteSize(free),
 			humanize.ByteSize(e.Leto.DiskLimit))
 	}
+	return nil
+}
 
-	return e.buildArtemisCommand()
+// CheckDiskSpace returns an error if there is not enough space left
+// on the disk where the experiment would be written. Unlike SetUp, it
+// does not create any directory.
+func (e *TrackingEnvironment) CheckDiskSpace() error {
+	dir := e.ExperimentDir
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	free, _, err := getDiskSize(dir)
+	if err != nil {
+		return err
+	}
+	return e.checkFreeSpace(free)
+}
+
+// SlaveStartRequest returns the request starting the experiment on
+// the slave name, processing the frames assigned to it in loads.
+func (e *TrackingEnvironment) SlaveStartRequest(name string, loads *leto.LoadBalancing) (*letopb.StartRequest, error) {
+	slaveConfig := *e.Config
+	slaveLoads := *loads
+	slaveLoads.SelfUUID = loads.UUIDs[name]
+	slaveConfig.Loads = &slaveLoads
+	asYaml, err := slaveConfig.Yaml()
+	if err != nil {
+		return nil, fmt.Errorf("could not serialize config: %s", err)
+	}
+	return &letopb.StartRequest{
+		YamlConfiguration: string(asYaml),
+	}, nil
 }
 
 func (e *TrackingEnvironment) makeAllDestinationDirs() error {
 	target := e.ExperimentDir
 	if e.Node.IsMaster() == true {
-		target = e.newAntPath()
+		target = e.Path("ants")
 	}
 	if err := os.MkdirAll(target, 0755); err != nil {
 		return fmt.Errorf("could not create %s: %w", target, err)
 	}
+	if e.Rotation == nil {
+		return nil
+	}
+	if err := e.Rotation.linkAnts(e.CurrentExperimentDir()); err != nil {
+		return fmt.Errorf("could not link new ant output: %w", err)
+	}
 	return nil
 }
 
-func (e *TrackingEnvironment) saveLocalConfig() error {
-	return e.Config.WriteConfiguration(e.Path("leto-final-config.yaml"))
+// saveLocalConfig saves the configuration and its provenance in the
+// experiment directory dir.
+func (e *TrackingEnvironment) saveLocalConfig(dir string) error {
+	if err := e.Config.WriteConfiguration(filepath.Join(dir, "leto-final-config.yaml")); err != nil {
+		return err
+	}
+	return e.saveProvenance(dir)
+}
+
+func (e *TrackingEnvironment) saveProvenance(dir string) error {
+	data, err := yaml.Marshal(e.Provenance)
+	if err != nil {
+		return fmt.Errorf("Could not encode configuration provenance: %s", err)
+	}
+	filename := filepath.Join(dir, "leto-config-provenance.yaml")
+	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("Could not write '%s': %s", filename, err)
+	}
+	return nil
+}
+
+// recordConfigurationChanges appends changes, applied at now, to the
+// configuration change log of the current experiment directory, and
+// saves the new configuration.
+func (e *TrackingEnvironment) recordConfigurationChanges(changes []leto.ConfigurationChange, now time.Time) error {
+	for _, c := range changes {
+		e.Provenance[c.Path] = leto.UpdateLayer
+	}
+
+	filename := filepath.Join(e.CurrentExperimentDir(), "leto-config-changes.log")
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("Could not open '%s': %s", filename, err)
+	}
+	defer f.Close()
+	for _, c := range changes {
+		if _, err := fmt.Fprintf(f, "%s %s\n", now.Format(time.RFC3339), c); err != nil {
+			return fmt.Errorf("Could not write '%s': %s", filename, err)
+		}
+	}
+
+	return e.saveLocalConfig(e.CurrentExperimentDir())
 }
 
 func (e *TrackingEnvironment) buildArtemisCommand() (*exec.Cmd, error) {
@@ -330,7 +547,8 @@ func (e *TrackingEnvironment) buildArtemisCommand() (*exec.Cmd, error) {
 	if err != nil {
 		return nil, err
 	}
-	cmd.Stderr, err = os.Create(e.Path("artemis.stderr"))
+	// a resumed experiment appends to the stderr of former runs.
+	cmd.Stderr, err = os.OpenFile(e.Path("artemis.stderr"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return nil, err
 	}
@@ -351,16 +569,75 @@ func (e *TrackingEnvironment) saveArtemisCommand(cmd *exec.Cmd) error {
 	return err
 }
 
+// Pause records that the experiment is paused since now.
+func (e *TrackingEnvironment) Pause(now time.Time) {
+	e.Pauses = append(e.Pauses, &letopb.ExperimentPause{Start: timestamppb.New(now)})
+}
+
+// PausedSince returns the start of the current pause, or a nil
+// timestamp if the experiment is not paused.
+func (e *TrackingEnvironment) PausedSince() *timestamppb.Timestamp {
+	if len(e.Pauses) == 0 || e.Pauses[len(e.Pauses)-1].End != nil {
+		return nil
+	}
+	return e.Pauses[len(e.Pauses)-1].Start
+}
+
+// ResetBalancing resets the workload balance before the tracking is
+// started again, as the clocks of the restarted trackers start over.
+func (e *TrackingEnvironment) ResetBalancing() {
+	e.Balancing = newWorkloadBalance(e.Config.Loads, *e.Config.Camera.FPS)
+}
+
+// Resume ends the current pause at now.
+func (e *TrackingEnvironment) Resume(now time.Time) {
+	if e.PausedSince() == nil {
+		return
+	}
+	e.Pauses[len(e.Pauses)-1].End = timestamppb.New(now)
+}
+
+// nextFrameIDOffset returns the FrameIDOffset of the next run of the
+// tracking, once lastFrameID was the last frame written. It is a
+// multiple of the stride, so each node keeps its frame IDs.
+func (e *TrackingEnvironment) nextFrameIDOffset(lastFrameID int64) int64 {
+	if lastFrameID < e.FrameIDOffset {
+		// nothing was written.
+		return e.FrameIDOffset
+	}
+	stride := int64(e.Balancing.Stride)
+	return (lastFrameID/stride + 1) * stride
+}
+
 func (e *TrackingEnvironment) TearDown(err error) (*letopb.ExperimentLog, error) {
 	log := e.buildLog(err)
+	if err := e.removeAntLink(); err != nil {
+		return log, err
+	}
 	return log, e.removeTestExperimentData()
 }
 
+func (e *TrackingEnvironment) removeAntLink() error {
+	if e.Rotation == nil {
+		return nil
+	}
+	return os.RemoveAll(e.Rotation.antLink)
+}
+
 func (e *TrackingEnvironment) removeTestExperimentData() error {
 	if e.TestMode == false {
 		return nil
 	}
-	return os.RemoveAll(e.ExperimentDir)
+	dirs := []string{e.ExperimentDir}
+	if e.Rotation != nil {
+		dirs = e.Rotation.Directories()
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (e *TrackingEnvironment) buildLog(err error) *letopb.ExperimentLog {
@@ -385,15 +662,22 @@ func (e *TrackingEnvironment) buildLog(err error) *letopb.ExperimentLog {
 		yaml = []byte(fmt.Sprintf("could not generate yaml config: %s", err))
 	}
 
+	if e.PausedSince() != nil {
+		// the experiment was stopped while paused.
+		e.Pauses[len(e.Pauses)-1].End = timestamppb.New(end)
+	}
+
 	return &letopb.ExperimentLog{
-		HasError:          hasError,
-		Error:             errorDescription,
-		ExperimentDir:     filepath.Base(e.ExperimentDir),
-		Start:             timestamppb.New(e.Start),
-		End:               timestamppb.New(end),
-		YamlConfiguration: string(yaml),
-		Log:               string(log),
-		Stderr:            string(stderr),
+		HasError:                hasError,
+		Error:                   errorDescription,
+		ExperimentDir:           filepath.Base(e.ExperimentDir),
+		Start:                   timestamppb.New(e.Start),
+		End:                     timestamppb.New(end),
+		YamlConfiguration:       string(yaml),
+		Log:                     string(log),
+		Stderr:                  string(stderr),
+		ConfigurationProvenance: e.Provenance,
+		Pauses:                  e.Pauses,
 	}
 }
 
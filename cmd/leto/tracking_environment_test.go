@@ -0,0 +1,67 @@
+package main
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type LoadBalancingSuite struct{}
+
+var _ = Suite(&LoadBalancingSuite{})
+
+func (s *LoadBalancingSuite) TestWeightedSlots(c *C) {
+	testdata := []struct {
+		Weights  map[string]int
+		Expected []int
+	}{
+		{nil, []int{0, 1, 2}},
+		{map[string]int{"localhost": 2}, []int{0, 1, 2, 0}},
+		{map[string]int{"localhost": 3, "b": 2}, []int{0, 2, 0, 1, 2, 0}},
+	}
+
+	for _, d := range testdata {
+		node := NodeConfiguration{Slaves: []string{"a", "b"}, Weights: d.Weights}
+		c.Check(weightedSlots([]string{"localhost", "a", "b"}, node), DeepEquals, d.Expected, Commentf("weights: %v", d.Weights))
+	}
+}
+
+func (s *LoadBalancingSuite) TestWeightedWorkloadIsComplete(c *C) {
+	node := NodeConfiguration{
+		Slaves:  []string{"a", "b"},
+		Weights: map[string]int{"localhost": 2, "b": 3},
+	}
+	c.Assert(node.CheckWeights(), IsNil)
+	loads := generateLoadBalancing(node)
+	c.Check(loads.Assignements, HasLen, 6)
+
+	wb := newWorkloadBalance(loads, 30.0)
+	c.Check(wb.Stride, Equals, 6)
+	c.Check(wb.Check(), IsNil)
+	c.Check(wb.framesPerStride(loads.UUIDs["localhost"]), Equals, 2)
+	c.Check(wb.framesPerStride(loads.UUIDs["a"]), Equals, 1)
+	c.Check(wb.framesPerStride(loads.UUIDs["b"]), Equals, 3)
+}
+
+func (s *LoadBalancingSuite) TestInvalidWeights(c *C) {
+	node := NodeConfiguration{
+		Slaves:  []string{"a"},
+		Weights: map[string]int{"a": 0},
+	}
+	c.Check(node.CheckWeights(), ErrorMatches, "NodeConfiguration: invalid weight 0 for a: it should be at least 1")
+}
+
+func (s *LoadBalancingSuite) TestNextFrameIDOffsetKeepsStride(c *C) {
+	node := NodeConfiguration{Slaves: []string{"a", "b"}}
+	loads := generateLoadBalancing(node)
+	e := &TrackingEnvironment{Balancing: newWorkloadBalance(loads, 30.0)}
+	c.Assert(e.Balancing.Stride, Equals, 3)
+
+	// nothing written yet.
+	c.Check(e.nextFrameIDOffset(-1), Equals, int64(0))
+	c.Check(e.nextFrameIDOffset(0), Equals, int64(3))
+	c.Check(e.nextFrameIDOffset(2), Equals, int64(3))
+	c.Check(e.nextFrameIDOffset(3), Equals, int64(6))
+
+	e.FrameIDOffset = 6
+	c.Check(e.nextFrameIDOffset(4), Equals, int64(6))
+	c.Check(e.nextFrameIDOffset(7), Equals, int64(9))
+}
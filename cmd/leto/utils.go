@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 func FilenameWithSuffix(fpath string, iter int) string {
@@ -30,3 +32,16 @@ func FilenameWithoutOverwrite(fpath string) (string, int, error) {
 		iter += 1
 	}
 }
+
+// A countingWriter counts the bytes written to an underlying
+// io.Writer. The count could be read concurrently.
+type countingWriter struct {
+	w     io.Writer
+	count *atomic.Int64
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.count.Add(int64(n))
+	return n, err
+}
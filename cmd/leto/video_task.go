@@ -85,6 +85,8 @@ func (cmd *FFMpegCommand) Wait() error {
 
 type VideoTask interface {
 	Run(io.ReadCloser) error
+	// Reconfigure applies config to the next video segments.
+	Reconfigure(config leto.StreamConfiguration)
 }
 
 type videoFilename struct {
@@ -142,7 +144,7 @@ type videoTaskConfig struct {
 
 	hostname string
 
-	period time.Duration
+	maxBytes int64
 
 	fps         float64
 	bitrate     int
@@ -153,25 +155,30 @@ type videoTaskConfig struct {
 	tune        string
 }
 
-func newVideoTaskConfig(basedir string, fps float64, config leto.StreamConfiguration) (videoTaskConfig, error) {
+func newVideoTaskConfig(basedir string, fps float64, config leto.StreamConfiguration, output leto.OutputConfiguration) (videoTaskConfig, error) {
 	hostname, err := os.Hostname()
 	if err != nil {
 		return videoTaskConfig{}, err
 	}
 
-	return videoTaskConfig{
+	res := videoTaskConfig{
 		hostname:     hostname,
 		baseFileName: NewBaseVideoName(basedir),
 		fps:          fps,
-		bitrate:      *config.BitRateKB,
-		maxBitrate:   int(float64(*config.BitRateKB) * *config.BitRateMaxRatio),
-		destAddress:  *config.Host,
 		resolution:   "",
-		quality:      *config.Quality,
-		tune:         *config.Tune,
 
-		period: 2 * time.Hour,
-	}, nil
+		maxBytes: int64(*output.VideoMaxSizeMB) * 1024 * 1024,
+	}
+	res.setStream(config)
+	return res, nil
+}
+
+func (c *videoTaskConfig) setStream(config leto.StreamConfiguration) {
+	c.bitrate = *config.BitRateKB
+	c.maxBitrate = int(float64(*config.BitRateKB) * *config.BitRateMaxRatio)
+	c.destAddress = *config.Host
+	c.quality = *config.Quality
+	c.tune = *config.Tune
 }
 
 type videoTask struct {
@@ -183,20 +190,38 @@ type videoTask struct {
 	encodeDone, streamDone, saveDone <-chan struct{}
 
 	frameCorrespondance *os.File
+	saved               atomic.Int64
+
+	boundaries *segmentBoundaries
+	pending    segmentBoundaryQueue
+	offset     uint64
+
+	// mx protects nextStream, set by Reconfigure.
+	mx         sync.Mutex
+	nextStream *leto.StreamConfiguration
 
 	logger *logrus.Entry
 	meter  metric.Meter
 }
 
-func NewVideoManager(ctx context.Context, basedir string, fps float64, config leto.StreamConfiguration) (VideoTask, error) {
-	conf, err := newVideoTaskConfig(basedir, fps, config)
+// NewVideoManager creates a VideoTask saving video segments in
+// basedir. A new segment is started on each frame boundary decided by
+// boundaries, and a boundary is requested once a segment reaches the
+// maximal size in output. A boundary carrying a directory starts the
+// new segment in that directory. boundaries could be nil.
+// frameIDOffset is added to the frame IDs read from artemis.
+func NewVideoManager(ctx context.Context, basedir string, fps float64, config leto.StreamConfiguration, output leto.OutputConfiguration, boundaries *segmentBoundaries, frameIDOffset int64) (VideoTask, error) {
+	conf, err := newVideoTaskConfig(basedir, fps, config, output)
 	if err != nil {
 		return nil, err
 	}
 	res := &videoTask{
-		config: conf,
-		logger: tm.NewLogger("video").WithContext(ctx),
-		meter:  otel.Meter(instrumentationName),
+		config:     conf,
+		boundaries: boundaries,
+		pending:    newSegmentBoundaryQueue(boundaries),
+		offset:     uint64(frameIDOffset),
+		logger:     tm.NewLogger("video").WithContext(ctx),
+		meter:      otel.Meter(instrumentationName),
 	}
 	if err := res.Check(); err != nil {
 		return nil, err
@@ -239,6 +264,23 @@ func (m *videoTask) Check() error {
 	return m.config.Check()
 }
 
+// Reconfigure applies config to the next video segments. As the
+// stream could only be changed with a new encoding, a new segment is
+// requested right away.
+func (s *videoTask) Reconfigure(config leto.StreamConfiguration) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.nextStream = &config
+}
+
+func (s *videoTask) takeReconfiguration() *leto.StreamConfiguration {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	res := s.nextStream
+	s.nextStream = nil
+	return res
+}
+
 func TeeCopy(dst, dstErrorIgnored io.Writer, src io.Reader) (int64, error) {
 	size := 32 * 1024
 	if l, ok := src.(*io.LimitedReader); ok && int64(size) > l.N {
@@ -276,13 +318,17 @@ func TeeCopy(dst, dstErrorIgnored io.Writer, src io.Reader) (int64, error) {
 
 func (s *videoTask) copyToSave() (int64, error) {
 	defer s.saveCmd.Stdin().Close()
-	return io.Copy(s.saveCmd.Stdin(), s.encodeCmd.Stdout())
+	return io.Copy(countingWriter{w: s.saveCmd.Stdin(), count: &s.saved}, s.encodeCmd.Stdout())
 }
 
 func (s *videoTask) copyToSaveAndEncode() (int64, error) {
 	defer s.saveCmd.Stdin().Close()
 	defer s.streamCmd.Stdin().Close()
-	return TeeCopy(s.saveCmd.Stdin(), s.streamCmd.Stdin(), s.encodeCmd.Stdout())
+	return TeeCopy(countingWriter{w: s.saveCmd.Stdin(), count: &s.saved}, s.streamCmd.Stdin(), s.encodeCmd.Stdout())
+}
+
+func (s *videoTask) segmentFull() bool {
+	return s.config.maxBytes > 0 && s.saved.Load() >= s.config.maxBytes
 }
 
 func (s *videoTask) copyRoutine() (int64, error) {
@@ -318,6 +364,7 @@ func (s *videoTask) startTasks() error {
 	if err != nil {
 		return err
 	}
+	s.saved.Store(0)
 
 	s.encodeCmd, err = NewFFMpegCommand(s.config.encodeCommandArgs(), filenames.encodeLog)
 	if err != nil {
@@ -445,7 +492,7 @@ func (s *videoTask) Run(muxed io.ReadCloser) (retError error) {
 	header := make([]byte, 3*8)
 
 	currentFrame := 0
-	nextFile := time.Now().Add(s.config.period)
+	requested := false
 
 	headerError := 0
 	maxHeaderTrials := 1920 * 1024 * 3 * 30
@@ -475,7 +522,7 @@ func (s *videoTask) Run(muxed io.ReadCloser) (retError error) {
 			headerError = 0
 		}
 
-		actual := binary.LittleEndian.Uint64(header)
+		actual := binary.LittleEndian.Uint64(header) + s.offset
 		width := binary.LittleEndian.Uint64(header[8:])
 		height := binary.LittleEndian.Uint64(header[16:])
 
@@ -490,12 +537,27 @@ func (s *videoTask) Run(muxed io.ReadCloser) (retError error) {
 			s.config.resolution = fmt.Sprintf("%dx%d", width, height)
 		}
 
+		// the boundary frame is the first of the new segment.
+		reached, dir := s.pending.Reached(int64(actual))
+		if len(dir) > 0 {
+			s.logger.WithField("directory", dir).Info("moving film segments to new experiment directory")
+			s.config.baseFileName = NewBaseVideoName(dir)
+		}
+		if reached == true && s.encodeCmd != nil {
+			s.logger.WithField("frameID", actual).Info("creating new film segment")
+
+			s.stopTasks()
+			s.waitTasks()
+
+			s.logger.WithField("frames", currentFrame).Info("frame written")
+		}
+
 		if s.encodeCmd == nil && s.streamCmd == nil && s.frameCorrespondance == nil {
 			if err := s.startTasks(); err != nil {
 				return fmt.Errorf("could not start stream tasks: %w", err)
 			}
 			currentFrame = 0
-			nextFile = time.Now().Add(s.config.period)
+			requested = false
 		}
 
 		fmt.Fprintf(s.frameCorrespondance, "%d %d\n", currentFrame, actual)
@@ -513,14 +575,21 @@ func (s *videoTask) Run(muxed io.ReadCloser) (retError error) {
 		}
 		currentFrame += 1
 
-		now := time.Now()
-		if now.After(nextFile) == true {
-			s.logger.Printf("creating new film segment after %s", s.config.period)
-
-			s.stopTasks()
-			s.waitTasks()
+		if next := s.takeReconfiguration(); next != nil {
+			s.logger.WithField("host", *next.Host).Info("stream reconfigured")
+			s.config.setStream(*next)
+			if s.boundaries == nil {
+				s.stopTasks()
+				s.waitTasks()
+				continue
+			}
+			s.boundaries.Request()
+		}
 
-			s.logger.WithField("frames", currentFrame).Info("frame written")
+		if s.boundaries != nil && requested == false && s.segmentFull() == true {
+			s.logger.WithField("bytes", s.saved.Load()).Info("film segment is full")
+			requested = true
+			s.boundaries.Request()
 		}
 	}
 
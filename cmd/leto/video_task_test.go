@@ -51,7 +51,7 @@ func (s *VideoTaskSuite) SetUpSuite(c *C) {
 	dir, err := os.MkdirTemp("", "leto-video-task-tests")
 	c.Assert(err, IsNil)
 
-	s.config, err = newVideoTaskConfig(dir, 8.0, streamConfiguration)
+	s.config, err = newVideoTaskConfig(dir, 8.0, streamConfiguration, leto.RecommendedOutputConfiguration())
 	s.config.resolution = "240x240"
 
 	c.Assert(err, IsNil)
@@ -89,9 +89,12 @@ func (s *VideoTaskSuite) TestE2E(c *C) {
 	dir := filepath.Join(s.Basedir(), "e2e")
 	c.Assert(os.MkdirAll(dir, 0755), IsNil)
 
-	v, err := NewVideoManager(context.Background(), dir, 8.0, streamConfiguration)
-	v.(*videoTask).config.period = 80 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	boundaries := newSegmentBoundaries(ctx, 80*time.Millisecond, 8.0, nil)
+	v, err := NewVideoManager(ctx, dir, 8.0, streamConfiguration, leto.RecommendedOutputConfiguration(), boundaries, 0)
 	c.Assert(err, IsNil)
+	go boundaries.Run(ctx)
 
 	in, out := io.Pipe()
 
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	"github.com/formicidae-tracker/olympus/pkg/tm"
+	"github.com/sirupsen/logrus"
+)
+
+// producerDeadline is how long a producer responsible for a single
+// frame per stride can miss all its frames before being considered
+// dead.
+const producerDeadline = 10 * time.Second
+
+// deadAfterTimeouts returns the WorkloadBalance.DeadAfter value
+// matching producerDeadline.
+func deadAfterTimeouts(FPS float64, stride int) int {
+	if FPS <= 0.0 || stride <= 1 {
+		return 0
+	}
+	return int(math.Ceil(producerDeadline.Seconds() * FPS / float64(stride)))
+}
+
+// A workloadRebalancer reassigns the frames of dead slaves to the
+// surviving ones, and restarts them with their new frame IDs. The
+// master is never restarted, as it drives the camera acquisition, so
+// it never takes over the frames of a dead slave: if no slave
+// survives, they are timeouted until the end of the experiment.
+type workloadRebalancer struct {
+	ctx context.Context
+
+	mx    sync.Mutex
+	loads *leto.LoadBalancing
+
+	dead           chan string
+	reassignations chan map[string][]bool
+	commit         func(loads *leto.LoadBalancing)
+	restart        func(name string, loads *leto.LoadBalancing) error
+	logger         *logrus.Entry
+}
+
+// newWorkloadRebalancer creates a workloadRebalancer starting from
+// loads. commit, which could be nil, is called with each new
+// assignation before any slave is restarted, and restart for each
+// slave which should track new frames.
+func newWorkloadRebalancer(ctx context.Context, loads *leto.LoadBalancing, commit func(loads *leto.LoadBalancing), restart func(name string, loads *leto.LoadBalancing) error) *workloadRebalancer {
+	return &workloadRebalancer{
+		ctx:            ctx,
+		loads:          loads,
+		dead:           make(chan string, len(loads.UUIDs)),
+		reassignations: make(chan map[string][]bool),
+		commit:         commit,
+		restart:        restart,
+		logger:         tm.NewLogger("rebalancer").WithContext(ctx),
+	}
+}
+
+// ReportDead reports that the producer with UUID puuid is
+// dead. It never blocks.
+func (r *workloadRebalancer) ReportDead(puuid string) {
+	select {
+	case r.dead <- puuid:
+	default:
+	}
+}
+
+// Reassignations returns the channel where new frame IDs by producer
+// UUID are published.
+func (r *workloadRebalancer) Reassignations() <-chan map[string][]bool {
+	return r.reassignations
+}
+
+// Loads returns the current assignation of frames.
+func (r *workloadRebalancer) Loads() *leto.LoadBalancing {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.loads
+}
+
+func (r *workloadRebalancer) Run() error {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return nil
+		case puuid := <-r.dead:
+			if err := r.rebalance(puuid); err != nil {
+				r.logger.WithError(err).WithField("producer", puuid).Error("could not rebalance workload")
+			}
+		}
+	}
+}
+
+func (r *workloadRebalancer) rebalance(dead string) error {
+	loads, restarts, err := reassignLoads(r.Loads(), dead)
+	if err != nil {
+		return err
+	}
+	r.mx.Lock()
+	r.loads = loads
+	r.mx.Unlock()
+	if r.commit != nil {
+		r.commit(loads)
+	}
+
+	select {
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	case r.reassignations <- newWorkloadBalance(loads, 0.0).IDsByUUID:
+	}
+
+	var errs []error
+	for _, name := range restarts {
+		r.logger.WithField("slave", name).Info("restarting slave with new frame IDs")
+		if err := r.restart(name, loads); err != nil {
+			errs = append(errs, fmt.Errorf("could not restart slave %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reassignLoads returns a new LoadBalancing where all frames of the
+// dead slave are assigned to the surviving slaves, in turn, and the
+// names of the slaves which received new frames.
+func reassignLoads(loads *leto.LoadBalancing, dead string) (*leto.LoadBalancing, []string, error) {
+	if dead == loads.UUIDs["localhost"] {
+		return nil, nil, errors.New("master cannot be replaced")
+	}
+
+	assigned := make(map[string]bool)
+	for _, puuid := range loads.Assignements {
+		assigned[puuid] = true
+	}
+	if assigned[dead] == false {
+		return nil, nil, fmt.Errorf("unknown or already removed producer %s", dead)
+	}
+
+	survivors := []string{}
+	for name, puuid := range loads.UUIDs {
+		if name == "localhost" || puuid == dead || assigned[puuid] == false {
+			continue
+		}
+		survivors = append(survivors, name)
+	}
+	if len(survivors) == 0 {
+		return nil, nil, errors.New("no surviving slave to take over its frames")
+	}
+	sort.Strings(survivors)
+
+	ids := make([]int, 0, len(loads.Assignements))
+	for id := range loads.Assignements {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	res := *loads
+	res.Assignements = make(map[int]string, len(loads.Assignements))
+	restarted := make(map[string]bool)
+	next := 0
+	for _, id := range ids {
+		puuid := loads.Assignements[id]
+		if puuid == dead {
+			name := survivors[next%len(survivors)]
+			next += 1
+			puuid = loads.UUIDs[name]
+			restarted[name] = true
+		}
+		res.Assignements[id] = puuid
+	}
+
+	restarts := make([]string, 0, len(restarted))
+	for _, name := range survivors {
+		if restarted[name] == true {
+			restarts = append(restarts, name)
+		}
+	}
+	return &res, restarts, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/formicidae-tracker/leto/internal/leto"
+	. "gopkg.in/check.v1"
+)
+
+type WorkloadRebalancerSuite struct {
+	loads *leto.LoadBalancing
+}
+
+var _ = Suite(&WorkloadRebalancerSuite{})
+
+func (s *WorkloadRebalancerSuite) SetUpTest(c *C) {
+	s.loads = &leto.LoadBalancing{
+		SelfUUID: "m",
+		UUIDs: map[string]string{
+			"localhost": "m",
+			"a":         "a-uuid",
+			"b":         "b-uuid",
+			"c":         "c-uuid",
+		},
+		Assignements: map[int]string{
+			0: "m",
+			1: "a-uuid",
+			2: "b-uuid",
+			3: "c-uuid",
+			4: "b-uuid",
+		},
+	}
+}
+
+func (s *WorkloadRebalancerSuite) TestDeadAfter(c *C) {
+	c.Check(deadAfterTimeouts(8.0, 1), Equals, 0)
+	c.Check(deadAfterTimeouts(8.0, 3), Equals, 27)
+}
+
+func (s *WorkloadRebalancerSuite) TestReassignLoads(c *C) {
+	res, restarts, err := reassignLoads(s.loads, "b-uuid")
+	c.Assert(err, IsNil)
+	c.Check(restarts, DeepEquals, []string{"a", "c"})
+	c.Check(res.Assignements, DeepEquals, map[int]string{
+		0: "m",
+		1: "a-uuid",
+		2: "a-uuid",
+		3: "c-uuid",
+		4: "c-uuid",
+	})
+	// the original is left untouched.
+	c.Check(s.loads.Assignements[2], Equals, "b-uuid")
+
+	res, restarts, err = reassignLoads(res, "a-uuid")
+	c.Assert(err, IsNil)
+	c.Check(restarts, DeepEquals, []string{"c"})
+	c.Check(res.Assignements, DeepEquals, map[int]string{
+		0: "m",
+		1: "c-uuid",
+		2: "c-uuid",
+		3: "c-uuid",
+		4: "c-uuid",
+	})
+
+	_, _, err = reassignLoads(res, "a-uuid")
+	c.Check(err, ErrorMatches, "unknown or already removed producer a-uuid")
+	_, _, err = reassignLoads(res, "c-uuid")
+	c.Check(err, ErrorMatches, "no surviving slave to take over its frames")
+	_, _, err = reassignLoads(res, "m")
+	c.Check(err, ErrorMatches, "master cannot be replaced")
+}
+
+func (s *WorkloadRebalancerSuite) TestRestartsSurvivors(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	restarted := make(chan string, 4)
+	var committed *leto.LoadBalancing
+	commit := func(loads *leto.LoadBalancing) {
+		committed = loads
+	}
+	r := newWorkloadRebalancer(ctx, s.loads, commit, func(name string, loads *leto.LoadBalancing) error {
+		c.Check(loads.Assignements[1], Equals, "b-uuid")
+		// the new loads are saved before any restart.
+		c.Check(committed, Equals, loads)
+		restarted <- name
+		return nil
+	})
+	errs := Start(r)
+
+	r.ReportDead("a-uuid")
+	select {
+	case IDs := <-r.Reassignations():
+		c.Check(IDs, DeepEquals, map[string][]bool{
+			"m":      {true, false, false, false, false},
+			"b-uuid": {false, true, true, false, true},
+			"c-uuid": {false, false, false, true, false},
+		})
+	case <-time.After(500 * time.Millisecond):
+		c.Fatalf("no reassignation published")
+	}
+	select {
+	case name := <-restarted:
+		c.Check(name, Equals, "b")
+	case <-time.After(500 * time.Millisecond):
+		c.Fatalf("no slave restarted")
+	}
+
+	cancel()
+	c.Check(<-errs, IsNil)
+	c.Check(restarted, HasLen, 0)
+}
@@ -0,0 +1,117 @@
+package leto
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// PresetBasePrefix prefixes the bases which are presets stored on a
+// node, other bases are YAML files.
+const PresetBasePrefix = "preset:"
+
+// ConfigurationBases lists the configurations a TrackingConfiguration
+// inherits from, in increasing priority. In YAML, it could be a
+// single value or a list.
+type ConfigurationBases []string
+
+func (b *ConfigurationBases) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*b = ConfigurationBases{single}
+		return nil
+	}
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*b = list
+	return nil
+}
+
+// A BaseResolver resolves the bases of tracking configurations.
+type BaseResolver struct {
+	// Preset returns the preset name, with its own bases. Preset
+	// bases are rejected if it is nil.
+	Preset func(name string) (*TrackingConfiguration, error)
+	// Files allows bases read from YAML files.
+	Files bool
+}
+
+// Resolve returns config merged over all its bases, resolved
+// recursively. Relative base files are read from dir. The result has
+// no bases.
+func (r BaseResolver) Resolve(config *TrackingConfiguration, dir string) (*TrackingConfiguration, error) {
+	return r.resolve(config, dir, nil)
+}
+
+// ReadFile reads the configuration in filename, and resolves its
+// bases.
+func (r BaseResolver) ReadFile(filename string) (*TrackingConfiguration, error) {
+	key, dir := r.locate(filename, "")
+	config, err := ReadConfiguration(filename)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(config, dir, []string{key})
+}
+
+func (r BaseResolver) resolve(config *TrackingConfiguration, dir string, stack []string) (*TrackingConfiguration, error) {
+	res := &TrackingConfiguration{}
+	for _, base := range config.Base {
+		key, baseDir := r.locate(base, dir)
+		for i, k := range stack {
+			if k == key {
+				return nil, fmt.Errorf("inheritance cycle: %s -> %s", strings.Join(stack[i:], " -> "), key)
+			}
+		}
+
+		baseConfig, err := r.load(base, key)
+		if err != nil {
+			return nil, fmt.Errorf("could not load base '%s': %w", base, err)
+		}
+		resolved, err := r.resolve(baseConfig, baseDir, append(stack[:len(stack):len(stack)], key))
+		if err != nil {
+			return nil, err
+		}
+		if err := res.Merge(resolved); err != nil {
+			return nil, fmt.Errorf("could not merge base '%s': %w", base, err)
+		}
+	}
+
+	if err := res.Merge(config); err != nil {
+		return nil, err
+	}
+	res.Base = nil
+	return res, nil
+}
+
+// locate returns the unique key of base, and the directory its own
+// bases are relative to.
+func (r BaseResolver) locate(base, dir string) (string, string) {
+	if strings.HasPrefix(base, PresetBasePrefix) == true {
+		return base, dir
+	}
+	path := base
+	if filepath.IsAbs(path) == false {
+		path = filepath.Join(dir, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path, filepath.Dir(path)
+}
+
+func (r BaseResolver) load(base, key string) (*TrackingConfiguration, error) {
+	if name, ok := strings.CutPrefix(base, PresetBasePrefix); ok == true {
+		if r.Preset == nil {
+			return nil, errors.New("presets are not available")
+		}
+		return r.Preset(name)
+	}
+	if r.Files == false {
+		return nil, errors.New("base files are not available, they should be resolved by the client")
+	}
+	return ReadConfiguration(key)
+}
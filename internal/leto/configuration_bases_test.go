@@ -0,0 +1,127 @@
+package leto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type BaseResolverSuite struct {
+	dir     string
+	presets map[string]string
+}
+
+var _ = Suite(&BaseResolverSuite{})
+
+func (s *BaseResolverSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	s.presets = make(map[string]string)
+}
+
+func (s *BaseResolverSuite) writeFile(c *C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	c.Assert(os.MkdirAll(filepath.Dir(path), 0755), IsNil)
+	c.Assert(os.WriteFile(path, []byte(content), 0644), IsNil)
+	return path
+}
+
+func (s *BaseResolverSuite) resolver() BaseResolver {
+	return BaseResolver{
+		Files: true,
+		Preset: func(name string) (*TrackingConfiguration, error) {
+			content, ok := s.presets[name]
+			if ok == false {
+				return nil, fmt.Errorf("unknown preset '%s'", name)
+			}
+			return ParseConfiguration([]byte(content))
+		},
+	}
+}
+
+func (s *BaseResolverSuite) TestInheritsFromFiles(c *C) {
+	s.writeFile(c, "species/bees.yml", `
+camera:
+  fps: 8
+apriltag:
+  family: 36h11
+`)
+	s.writeFile(c, "species/night.yml", `
+base: bees.yml
+camera:
+  strobe-duration: 2ms
+`)
+	s.writeFile(c, "fast.yml", `
+camera:
+  fps: 12
+threads: 4
+`)
+	path := s.writeFile(c, "experiments/exp.yml", `
+base: [../species/night.yml, ../fast.yml]
+experiment: exp
+threads: 2
+`)
+
+	config, err := s.resolver().ReadFile(path)
+	c.Assert(err, IsNil)
+	c.Check(config.Base, IsNil)
+	c.Check(config.ExperimentName, Equals, "exp")
+	c.Check(*config.Camera.FPS, Equals, 12.0)
+	c.Check(config.Camera.StrobeDuration.String(), Equals, "2ms")
+	c.Check(*config.Detection.Family, Equals, "36h11")
+	c.Check(*config.Threads, Equals, 2)
+}
+
+func (s *BaseResolverSuite) TestInheritsFromPresets(c *C) {
+	s.presets["bees"] = "apriltag:\n  family: 36h11\ncamera:\n  fps: 8\n"
+	s.presets["bees-6fps"] = "base: preset:bees\ncamera:\n  fps: 6\n"
+	path := s.writeFile(c, "exp.yml", "base: preset:bees-6fps\nexperiment: exp\n")
+
+	config, err := s.resolver().ReadFile(path)
+	c.Assert(err, IsNil)
+	c.Check(*config.Camera.FPS, Equals, 6.0)
+	c.Check(*config.Detection.Family, Equals, "36h11")
+
+	_, err = BaseResolver{Files: true}.ReadFile(path)
+	c.Check(err, ErrorMatches, "could not load base 'preset:bees-6fps': presets are not available")
+}
+
+func (s *BaseResolverSuite) TestDetectsCycles(c *C) {
+	s.writeFile(c, "a.yml", "base: b.yml\n")
+	s.writeFile(c, "b.yml", "base: c.yml\n")
+	s.writeFile(c, "c.yml", "base: a.yml\n")
+	_, err := s.resolver().ReadFile(filepath.Join(s.dir, "a.yml"))
+	c.Check(err, ErrorMatches, "inheritance cycle: .*/a.yml -> .*/b.yml -> .*/c.yml -> .*/a.yml")
+
+	s.presets["a"] = "base: preset:b"
+	s.presets["b"] = "base: preset:a"
+	config, err := ParseConfiguration([]byte("base: preset:a"))
+	c.Assert(err, IsNil)
+	_, err = s.resolver().Resolve(config, s.dir)
+	c.Check(err, ErrorMatches, "inheritance cycle: preset:a -> preset:b -> preset:a")
+}
+
+func (s *BaseResolverSuite) TestAllowsDiamonds(c *C) {
+	s.writeFile(c, "common.yml", "threads: 2\n")
+	s.writeFile(c, "a.yml", "base: common.yml\n")
+	s.writeFile(c, "b.yml", "base: common.yml\n")
+	config, err := s.resolver().ReadFile(s.writeFile(c, "exp.yml", "base: [a.yml, b.yml]\n"))
+	c.Assert(err, IsNil)
+	c.Check(*config.Threads, Equals, 2)
+}
+
+func (s *BaseResolverSuite) TestReportsBaseErrors(c *C) {
+	path := s.writeFile(c, "exp.yml", "base: missing.yml\n")
+	_, err := s.resolver().ReadFile(path)
+	c.Check(err, ErrorMatches, "could not load base 'missing.yml': Could not read .*")
+
+	config, err := ParseConfiguration([]byte("base: exp.yml\n"))
+	c.Assert(err, IsNil)
+	_, err = BaseResolver{}.Resolve(config, s.dir)
+	c.Check(err, ErrorMatches, "could not load base 'exp.yml': base files are not available, .*")
+
+	s.writeFile(c, "typo.yml", "camra:\n  fps: 8\n")
+	_, err = s.resolver().ReadFile(s.writeFile(c, "exp.yml", "base: typo.yml\n"))
+	c.Check(err, ErrorMatches, "could not load base 'typo.yml': .*/typo.yml:1:1: unknown field 'camra'")
+}
@@ -0,0 +1,51 @@
+package leto
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A ConfigurationChange is a leaf field of a TrackingConfiguration,
+// by its YAML path, modified by an update.
+type ConfigurationChange struct {
+	Path     string
+	Previous string
+	Value    string
+}
+
+func (c ConfigurationChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Previous, c.Value)
+}
+
+// ConfigurationChanges returns the fields set in update with a value
+// different than in current, in declaration order.
+func ConfigurationChanges(current, update *TrackingConfiguration) []ConfigurationChange {
+	values := make(map[string]reflect.Value)
+	walkConfigurationLeaves(reflect.ValueOf(current).Elem(), "", func(path string, v reflect.Value) {
+		values[path] = v
+	})
+
+	var res []ConfigurationChange
+	walkConfigurationLeaves(reflect.ValueOf(update).Elem(), "", func(path string, v reflect.Value) {
+		previous := values[path]
+		if v.IsZero() == true || reflect.DeepEqual(previous.Interface(), v.Interface()) == true {
+			return
+		}
+		res = append(res, ConfigurationChange{
+			Path:     path,
+			Previous: formatConfigurationValue(previous),
+			Value:    formatConfigurationValue(v),
+		})
+	})
+	return res
+}
+
+func formatConfigurationValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() == true {
+			return "~"
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
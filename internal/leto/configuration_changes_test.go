@@ -0,0 +1,32 @@
+package leto
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type ConfigurationChangesSuite struct{}
+
+var _ = Suite(&ConfigurationChangesSuite{})
+
+func (s *ConfigurationChangesSuite) TestReportsModifiedFields(c *C) {
+	current := RecommendedTrackingConfiguration()
+	current.ExperimentName = "foo"
+	update, err := ParseConfiguration([]byte(`
+experiment: foo
+stream:
+  host: olympus.local
+  bitrate: 4000
+  quality: fast
+image-renew-period: 1h
+highlights: [1, 2]
+`))
+	c.Assert(err, IsNil)
+
+	c.Check(ConfigurationChanges(&current, update), DeepEquals, []ConfigurationChange{
+		{Path: "image-renew-period", Previous: "2h0m0s", Value: "1h0m0s"},
+		{Path: "stream.host", Previous: "", Value: "olympus.local"},
+		{Path: "stream.bitrate", Previous: "2000", Value: "4000"},
+		{Path: "highlights", Previous: "[]", Value: "[1 2]"},
+	})
+	c.Check(ConfigurationChanges(&current, &TrackingConfiguration{}), HasLen, 0)
+}
@@ -0,0 +1,81 @@
+package leto
+
+import (
+	"reflect"
+)
+
+// SystemConfigPath is the node-wide configuration merged over the
+// recommended one.
+const SystemConfigPath = "/etc/default/leto.yml"
+
+// Layers of a final tracking configuration, in increasing priority.
+const (
+	RecommendedLayer = "recommended"
+	SystemLayer      = SystemConfigPath
+	// UserLayer is the configuration requested by the user, with its
+	// bases. On slaves, it is replaced by MasterLayer, the final
+	// configuration of the master.
+	UserLayer   = "user"
+	MasterLayer = "master"
+	// NodeLayer are the values computed by the node, like the load
+	// balancing.
+	NodeLayer = "node"
+	// UpdateLayer are the values changed on the running experiment.
+	UpdateLayer = "update"
+)
+
+// A ConfigurationProvenance records, for every leaf field of a
+// tracking configuration by its YAML path, the layer which supplied
+// its value.
+type ConfigurationProvenance map[string]string
+
+// NewConfigurationProvenance returns a ConfigurationProvenance where
+// all fields are supplied by layer.
+func NewConfigurationProvenance(layer string) ConfigurationProvenance {
+	res := make(ConfigurationProvenance)
+	for _, path := range ConfigurationPaths() {
+		res[path] = layer
+	}
+	return res
+}
+
+// Record records layer as the provenance of all fields set in
+// config.
+func (p ConfigurationProvenance) Record(layer string, config *TrackingConfiguration) {
+	walkConfigurationLeaves(reflect.ValueOf(config).Elem(), "", func(path string, v reflect.Value) {
+		if v.IsZero() == false {
+			p[path] = layer
+		}
+	})
+}
+
+// ConfigurationPaths returns the YAML paths of all leaf fields of a
+// TrackingConfiguration, in declaration order.
+func ConfigurationPaths() []string {
+	res := []string{}
+	walkConfigurationLeaves(reflect.New(reflect.TypeOf(TrackingConfiguration{})).Elem(), "", func(path string, _ reflect.Value) {
+		res = append(res, path)
+	})
+	return res
+}
+
+// walkConfigurationLeaves calls f on all leaf fields of v. Pointers,
+// even to structs, are leaves since they are merged as a whole.
+func walkConfigurationLeaves(v reflect.Value, path string, f func(path string, v reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() == false || field.Type == reflect.TypeOf(ConfigurationBases{}) {
+			continue
+		}
+		name := yamlFieldName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			walkConfigurationLeaves(v.Field(i), joinYAMLPath(path, name), f)
+			continue
+		}
+		f(joinYAMLPath(path, name), v.Field(i))
+	}
+}
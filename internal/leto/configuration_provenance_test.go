@@ -0,0 +1,47 @@
+package leto
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type ConfigurationProvenanceSuite struct{}
+
+var _ = Suite(&ConfigurationProvenanceSuite{})
+
+func (s *ConfigurationProvenanceSuite) TestPathsAreLeaves(c *C) {
+	paths := ConfigurationPaths()
+	c.Assert(len(paths) > 0, Equals, true)
+	c.Check(paths[0], Equals, "experiment")
+	known := make(map[string]bool)
+	for _, p := range paths {
+		known[p] = true
+	}
+	c.Check(known["base"], Equals, false)
+	c.Check(known["camera"], Equals, false)
+	for _, p := range []string{"camera.fps", "apriltag.quad.decimate", "load-balancing", "sinks", "restart-on-reboot"} {
+		c.Check(known[p], Equals, true, Commentf("path: %s", p))
+	}
+}
+
+func (s *ConfigurationProvenanceSuite) TestRecordsSetFields(c *C) {
+	config, err := ParseConfiguration([]byte(`
+experiment: foo
+camera:
+  fps: 6
+apriltag:
+  quad:
+    decimate: 2
+`))
+	c.Assert(err, IsNil)
+
+	provenance := NewConfigurationProvenance(RecommendedLayer)
+	c.Check(provenance, HasLen, len(ConfigurationPaths()))
+	provenance.Record(UserLayer, config)
+
+	c.Check(provenance["experiment"], Equals, UserLayer)
+	c.Check(provenance["camera.fps"], Equals, UserLayer)
+	c.Check(provenance["apriltag.quad.decimate"], Equals, UserLayer)
+	c.Check(provenance["apriltag.quad.sigma"], Equals, RecommendedLayer)
+	c.Check(provenance["camera.strobe-delay"], Equals, RecommendedLayer)
+	c.Check(provenance["restart-on-reboot"], Equals, RecommendedLayer)
+}
@@ -0,0 +1,217 @@
+package leto
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/formicidae-tracker/hermes"
+	"github.com/golang/protobuf/proto"
+)
+
+const uncompressedPrefix = "uncompressed-"
+
+// A HermesRecovery describes a tracking segment rebuilt from its
+// uncompressed twin.
+type HermesRecovery struct {
+	// Segment is the path of the rebuilt compressed segment.
+	Segment string
+	// Frames is the number of frames recovered.
+	Frames int
+	// Next is the segment following the recovered one, if any.
+	Next string
+	// Truncated is set if the uncompressed segment itself was
+	// truncated. All frames up to the truncation are recovered.
+	Truncated bool
+}
+
+// FindOrphanedHermesSegments returns all uncompressed tracking
+// segments in dir. Leto removes them once their compressed twin is
+// properly closed, so they only remain after a crash or a power loss.
+func FindOrphanedHermesSegments(dir string) ([]string, error) {
+	return filepath.Glob(filepath.Join(dir, uncompressedPrefix+"tracking.*.hermes"))
+}
+
+// nextHermesSegment returns the name of the segment following
+// segment in its directory, or an empty string if it is the last one.
+func nextHermesSegment(segment string) (string, error) {
+	segments, err := HermesSegments(filepath.Dir(segment))
+	if err != nil {
+		return "", err
+	}
+	idx := hermesSegmentIndex(segment)
+	for _, s := range segments {
+		if hermesSegmentIndex(s) > idx {
+			return filepath.Base(s), nil
+		}
+	}
+	return "", nil
+}
+
+// RecoverHermesSegment rebuilds a valid compressed segment, with a
+// proper footer, from an orphaned uncompressed segment. The footer
+// links to the next segment in the directory, if any. On success the
+// uncompressed segment is removed.
+func RecoverHermesSegment(uncompressed string) (*HermesRecovery, error) {
+	base := filepath.Base(uncompressed)
+	if strings.HasPrefix(base, uncompressedPrefix) == false {
+		return nil, fmt.Errorf("'%s' is not an uncompressed segment", uncompressed)
+	}
+
+	res := &HermesRecovery{
+		Segment: filepath.Join(filepath.Dir(uncompressed), strings.TrimPrefix(base, uncompressedPrefix)),
+	}
+	var err error
+	res.Next, err = nextHermesSegment(res.Segment)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := os.Open(uncompressed)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	header := &hermes.Header{}
+	if ok, err := hermes.ReadDelimitedMessage(in, header); err != nil || ok == false {
+		if err == nil {
+			err = errors.New("empty header")
+		}
+		return nil, fmt.Errorf("could not read header of '%s': %w", uncompressed, err)
+	}
+
+	res.Frames, err = writeHermesSegment(res.Segment, res.Segment+".recovering", header, in, res.Next,
+		func(err error) error {
+			// all frames up to the truncation are kept.
+			res.Truncated = err != io.EOF
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, os.Remove(uncompressed)
+}
+
+// RecoverHermesDirectory recovers all orphaned uncompressed segments
+// in dir.
+func RecoverHermesDirectory(dir string) ([]*HermesRecovery, error) {
+	orphans, err := FindOrphanedHermesSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*HermesRecovery, 0, len(orphans))
+	var errs []error
+	for _, o := range orphans {
+		r, err := RecoverHermesSegment(o)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		res = append(res, r)
+	}
+	return res, errors.Join(errs...)
+}
+
+// LinkHermesSegment rewrites the footer of the closed segment to
+// link it to next. It is used to continue the chain of a paused
+// experiment, whose last segment was closed without a next segment.
+func LinkHermesSegment(segment, next string) error {
+	in, err := os.Open(segment)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	gzIn, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("could not read '%s': %w", segment, err)
+	}
+	defer gzIn.Close()
+
+	header := &hermes.Header{}
+	if ok, err := hermes.ReadDelimitedMessage(gzIn, header); err != nil || ok == false {
+		if err == nil {
+			err = errors.New("empty header")
+		}
+		return fmt.Errorf("could not read header of '%s': %w", segment, err)
+	}
+
+	_, err = writeHermesSegment(segment, segment+".linking", header, gzIn, filepath.Base(next),
+		func(err error) error {
+			return fmt.Errorf("could not read '%s': %w", segment, err)
+		})
+	return err
+}
+
+// writeHermesSegment writes the compressed segment path, with header,
+// the frames read from in until its footer, and a footer linking to
+// next. It is written in tmpName, and only renamed to path once
+// complete. A read error ends the frames, and is passed to readError:
+// the segment is not written if it returns an error. It returns the
+// number of frames written.
+func writeHermesSegment(path, tmpName string, header *hermes.Header, in io.Reader, next string, readError func(error) error) (int, error) {
+	out, err := os.Create(tmpName)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		out.Close()
+		os.Remove(tmpName)
+	}()
+	gz := gzip.NewWriter(out)
+
+	write := func(m proto.Message) error {
+		b := proto.NewBuffer(nil)
+		if err := b.EncodeMessage(m); err != nil {
+			return err
+		}
+		_, err := gz.Write(b.Bytes())
+		return err
+	}
+
+	if err := write(header); err != nil {
+		return 0, fmt.Errorf("could not write header: %w", err)
+	}
+
+	frames := 0
+	for {
+		line := &hermes.FileLine{}
+		ok, err := hermes.ReadDelimitedMessage(in, line)
+		if err != nil {
+			if err := readError(err); err != nil {
+				return frames, err
+			}
+			break
+		}
+		if ok == false {
+			continue
+		}
+		if line.Footer != nil {
+			break
+		}
+		if line.Readout == nil {
+			continue
+		}
+		if err := write(line); err != nil {
+			return frames, fmt.Errorf("could not write frame: %w", err)
+		}
+		frames += 1
+	}
+
+	if err := write(&hermes.FileLine{Footer: &hermes.Footer{Next: next}}); err != nil {
+		return frames, fmt.Errorf("could not write footer: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return frames, fmt.Errorf("could not close gzip stream: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return frames, err
+	}
+	return frames, os.Rename(tmpName, path)
+}
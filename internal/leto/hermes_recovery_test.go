@@ -0,0 +1,103 @@
+package leto
+
+import (
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *HermesVerificationSuite) TestRecoverLastSegment(c *C) {
+	s.writeSegment(c, "tracking.0000.hermes", s.segmentData(c, testSegment{
+		Next:     "tracking.0001.hermes",
+		FrameIDs: []int64{0, 1},
+	}))
+	complete := s.segmentRaw(c, testSegment{
+		Previous: "tracking.0000.hermes",
+		FrameIDs: []int64{2, 3, 4, 5},
+		NoFooter: true,
+	})
+	// the power cut happened in the middle of the last frame
+	s.writeSegment(c, "uncompressed-tracking.0001.hermes", complete[:len(complete)-3])
+	gzipped := s.segmentData(c, testSegment{
+		Previous: "tracking.0000.hermes",
+		FrameIDs: []int64{2, 3},
+		NoFooter: true,
+	})
+	s.writeSegment(c, "tracking.0001.hermes", gzipped[:len(gzipped)/2])
+
+	orphans, err := FindOrphanedHermesSegments(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(orphans, DeepEquals, []string{filepath.Join(s.dir, "uncompressed-tracking.0001.hermes")})
+
+	recovered, err := RecoverHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Assert(recovered, HasLen, 1)
+	c.Check(recovered[0].Segment, Equals, filepath.Join(s.dir, "tracking.0001.hermes"))
+	c.Check(recovered[0].Frames, Equals, 3)
+	c.Check(recovered[0].Truncated, Equals, true)
+	c.Check(recovered[0].Next, Equals, "")
+
+	_, err = os.Stat(filepath.Join(s.dir, "uncompressed-tracking.0001.hermes"))
+	c.Check(os.IsNotExist(err), Equals, true)
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, true)
+	c.Check(report.Frames(), Equals, 5)
+}
+
+func (s *HermesVerificationSuite) TestRecoverLinksToNextSegment(c *C) {
+	s.writeSegment(c, "uncompressed-tracking.0000.hermes", s.segmentRaw(c, testSegment{
+		FrameIDs: []int64{0, 1},
+		NoFooter: true,
+	}))
+	s.writeSegment(c, "tracking.0001.hermes", s.segmentData(c, testSegment{
+		Previous: "tracking.0000.hermes",
+		FrameIDs: []int64{2},
+	}))
+
+	r, err := RecoverHermesSegment(filepath.Join(s.dir, "uncompressed-tracking.0000.hermes"))
+	c.Assert(err, IsNil)
+	c.Check(r.Next, Equals, "tracking.0001.hermes")
+	c.Check(r.Truncated, Equals, false)
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.ChainErrors, HasLen, 0)
+	c.Check(report.Ok(), Equals, true)
+}
+
+func (s *HermesVerificationSuite) TestRecoverFailsWithoutHeader(c *C) {
+	s.writeSegment(c, "uncompressed-tracking.0000.hermes", nil)
+	_, err := RecoverHermesSegment(filepath.Join(s.dir, "uncompressed-tracking.0000.hermes"))
+	c.Check(err, ErrorMatches, "could not read header of .*")
+
+	_, err = RecoverHermesSegment(filepath.Join(s.dir, "tracking.0000.hermes"))
+	c.Check(err, ErrorMatches, ".* is not an uncompressed segment")
+}
+
+func (s *HermesVerificationSuite) TestLinkSegment(c *C) {
+	s.writeSegment(c, "tracking.0000.hermes", s.segmentData(c, testSegment{
+		FrameIDs: []int64{0, 1, 2},
+	}))
+	s.writeSegment(c, "tracking.0001.hermes", s.segmentData(c, testSegment{
+		Previous: "tracking.0000.hermes",
+		FrameIDs: []int64{0, 1},
+	}))
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.ChainErrors, HasLen, 1)
+
+	c.Assert(LinkHermesSegment(filepath.Join(s.dir, "tracking.0000.hermes"), filepath.Join(s.dir, "tracking.0001.hermes")), IsNil)
+
+	report, err = VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.ChainErrors, HasLen, 0)
+	c.Check(report.Frames(), Equals, 5)
+	c.Check(report.Segments[0].Next, Equals, "tracking.0001.hermes")
+
+	_, err = os.Stat(filepath.Join(s.dir, "tracking.0000.hermes.linking"))
+	c.Check(os.IsNotExist(err), Equals, true)
+}
@@ -0,0 +1,272 @@
+package leto
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/formicidae-tracker/hermes"
+)
+
+// A HermesSegmentReport summarizes the content and integrity of a
+// single hermes tracking segment.
+type HermesSegmentReport struct {
+	Filename string
+
+	HasHeader bool
+	HasFooter bool
+	Previous  string
+	Next      string
+
+	Frames        int
+	FirstFrameID  int64
+	LastFrameID   int64
+	Start, End    time.Time
+	DroppedFrames int64
+	TimeoutFrames int
+
+	// Truncated is set if the file ended unexpectedly, either in the
+	// gzip stream or in the middle of a message. TruncationError
+	// describes the error that occured.
+	Truncated       bool
+	TruncationError string
+}
+
+// DroppedRatio returns the ratio of frames missing from the segment,
+// computed from the gaps in the frame IDs.
+func (r *HermesSegmentReport) DroppedRatio() float64 {
+	total := int64(r.Frames) + r.DroppedFrames
+	if total == 0 {
+		return 0.0
+	}
+	return float64(r.DroppedFrames) / float64(total)
+}
+
+// Ok returns true if the segment is complete.
+func (r *HermesSegmentReport) Ok() bool {
+	return r.HasHeader == true && r.HasFooter == true && r.Truncated == false
+}
+
+// A HermesReport summarizes all hermes tracking segments of an
+// experiment directory.
+type HermesReport struct {
+	Segments    []*HermesSegmentReport
+	ChainErrors []string
+}
+
+// Ok returns true if all segments are complete and form an unbroken
+// chain.
+func (r *HermesReport) Ok() bool {
+	if len(r.ChainErrors) > 0 {
+		return false
+	}
+	for _, s := range r.Segments {
+		if s.Ok() == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Frames returns the total number of frames in all segments.
+func (r *HermesReport) Frames() int {
+	res := 0
+	for _, s := range r.Segments {
+		res += s.Frames
+	}
+	return res
+}
+
+// VerifyHermesSegment reads a hermes tracking segment and reports its
+// content. Integrity issues are described in the report, an error is
+// only returned if the file could not be opened.
+func VerifyHermesSegment(filename string) (*HermesSegmentReport, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	res := &HermesSegmentReport{
+		Filename: filepath.Base(filename),
+	}
+
+	truncated := func(err error) (*HermesSegmentReport, error) {
+		res.Truncated = true
+		res.TruncationError = err.Error()
+		return res, nil
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return truncated(fmt.Errorf("invalid gzip stream: %w", err))
+	}
+	defer gz.Close()
+
+	header := &hermes.Header{}
+	if ok, err := hermes.ReadDelimitedMessage(gz, header); err != nil || ok == false {
+		if err == nil {
+			err = errors.New("empty header")
+		}
+		return truncated(fmt.Errorf("could not read header: %w", err))
+	}
+	res.HasHeader = true
+	res.Previous = header.Previous
+
+	for {
+		line := &hermes.FileLine{}
+		ok, err := hermes.ReadDelimitedMessage(gz, line)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return truncated(fmt.Errorf("after frame %d: %w", res.LastFrameID, err))
+		}
+		if ok == false {
+			continue
+		}
+
+		if line.Readout != nil {
+			res.addReadout(line.Readout)
+		}
+
+		if line.Footer != nil {
+			res.HasFooter = true
+			res.Next = line.Footer.Next
+			break
+		}
+	}
+
+	// reads until the end to check the gzip stream checksum.
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return truncated(fmt.Errorf("after footer: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *HermesSegmentReport) addReadout(ro *hermes.FrameReadout) {
+	if r.Frames > 0 && ro.FrameID > r.LastFrameID+1 {
+		r.DroppedFrames += ro.FrameID - r.LastFrameID - 1
+	}
+	if r.Frames == 0 {
+		r.FirstFrameID = ro.FrameID
+	}
+	r.LastFrameID = ro.FrameID
+	r.Frames += 1
+
+	if ro.Error == hermes.FrameReadout_PROCESS_TIMEOUT {
+		r.TimeoutFrames += 1
+	}
+
+	if ro.Time == nil {
+		return
+	}
+	t := ro.Time.AsTime()
+	if r.Start.IsZero() || t.Before(r.Start) {
+		r.Start = t
+	}
+	if t.After(r.End) {
+		r.End = t
+	}
+}
+
+// hermesSegmentIndex returns the numerical suffix of a tracking
+// segment, or -1 if p is not a tracking segment.
+func hermesSegmentIndex(p string) int {
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(p), "tracking."), ".hermes"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// HermesSegments returns all tracking segments in dir, ordered by
+// their numerical suffix.
+func HermesSegments(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "tracking.*.hermes"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if hermesSegmentIndex(m) >= 0 {
+			res = append(res, m)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return hermesSegmentIndex(res[i]) < hermesSegmentIndex(res[j])
+	})
+	return res, nil
+}
+
+// VerifyHermesDirectory verifies all hermes tracking segments in an
+// experiment directory, and checks that their Previous/Next chain is
+// unbroken. Frames missing between two segments are accounted in the
+// later one.
+func VerifyHermesDirectory(dir string) (*HermesReport, error) {
+	segments, err := HermesSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no hermes tracking segment in '%s'", dir)
+	}
+
+	res := &HermesReport{}
+	for _, s := range segments {
+		report, err := VerifyHermesSegment(s)
+		if err != nil {
+			return nil, err
+		}
+		res.Segments = append(res.Segments, report)
+	}
+
+	res.checkChain()
+	return res, nil
+}
+
+func (r *HermesReport) checkChain() {
+	for i, s := range r.Segments {
+		if i == 0 {
+			if len(s.Previous) > 0 {
+				r.ChainErrors = append(r.ChainErrors,
+					fmt.Sprintf("%s: previous segment '%s' is missing", s.Filename, s.Previous))
+			}
+		} else {
+			prev := r.Segments[i-1]
+			if s.HasHeader == true && s.Previous != prev.Filename {
+				r.ChainErrors = append(r.ChainErrors,
+					fmt.Sprintf("%s: previous segment is '%s', expected '%s'", s.Filename, s.Previous, prev.Filename))
+			}
+			if prev.Frames > 0 && s.Frames > 0 && s.FirstFrameID > prev.LastFrameID+1 {
+				s.DroppedFrames += s.FirstFrameID - prev.LastFrameID - 1
+			}
+		}
+
+		if s.HasFooter == false {
+			continue
+		}
+		next := ""
+		if i+1 < len(r.Segments) {
+			next = r.Segments[i+1].Filename
+		}
+		if s.Next != next {
+			if len(next) == 0 {
+				r.ChainErrors = append(r.ChainErrors,
+					fmt.Sprintf("%s: next segment '%s' is missing", s.Filename, s.Next))
+			} else {
+				r.ChainErrors = append(r.ChainErrors,
+					fmt.Sprintf("%s: next segment is '%s', expected '%s'", s.Filename, s.Next, next))
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package leto
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/formicidae-tracker/hermes"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	. "gopkg.in/check.v1"
+)
+
+type HermesVerificationSuite struct {
+	dir   string
+	start time.Time
+}
+
+var _ = Suite(&HermesVerificationSuite{})
+
+func (s *HermesVerificationSuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	s.start = time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+}
+
+type testSegment struct {
+	Previous, Next string
+	FrameIDs       []int64
+	Timeouts       map[int64]bool
+	NoFooter       bool
+}
+
+// segmentRaw returns the uncompressed content of a segment.
+func (s *HermesVerificationSuite) segmentRaw(c *C, seg testSegment) []byte {
+	buf := bytes.NewBuffer(nil)
+	write := func(m proto.Message) {
+		b := proto.NewBuffer(nil)
+		c.Assert(b.EncodeMessage(m), IsNil)
+		_, err := buf.Write(b.Bytes())
+		c.Assert(err, IsNil)
+	}
+
+	write(&hermes.Header{
+		Type:     hermes.Header_File,
+		Version:  &hermes.Version{Vmajor: 0, Vminor: 2},
+		Previous: seg.Previous,
+	})
+	for _, id := range seg.FrameIDs {
+		t := s.start.Add(time.Duration(id) * 100 * time.Millisecond)
+		ro := &hermes.FrameReadout{
+			FrameID: id,
+			Time:    &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())},
+		}
+		if seg.Timeouts[id] == true {
+			ro.Error = hermes.FrameReadout_PROCESS_TIMEOUT
+		}
+		write(&hermes.FileLine{Readout: ro})
+	}
+	if seg.NoFooter == false {
+		write(&hermes.FileLine{Footer: &hermes.Footer{Next: seg.Next}})
+	}
+	return buf.Bytes()
+}
+
+func (s *HermesVerificationSuite) segmentData(c *C, seg testSegment) []byte {
+	buf := bytes.NewBuffer(nil)
+	gz := gzip.NewWriter(buf)
+	_, err := gz.Write(s.segmentRaw(c, seg))
+	c.Assert(err, IsNil)
+	c.Assert(gz.Close(), IsNil)
+	return buf.Bytes()
+}
+
+func (s *HermesVerificationSuite) writeSegment(c *C, name string, data []byte) {
+	c.Assert(os.WriteFile(filepath.Join(s.dir, name), data, 0644), IsNil)
+}
+
+func (s *HermesVerificationSuite) TestCompleteChain(c *C) {
+	s.writeSegment(c, "tracking.0000.hermes", s.segmentData(c, testSegment{
+		Next:     "tracking.0001.hermes",
+		FrameIDs: []int64{0, 1, 2, 4},
+		Timeouts: map[int64]bool{2: true},
+	}))
+	s.writeSegment(c, "tracking.0001.hermes", s.segmentData(c, testSegment{
+		Previous: "tracking.0000.hermes",
+		FrameIDs: []int64{6, 7},
+	}))
+	// should be ignored
+	s.writeSegment(c, "uncompressed-tracking.0001.hermes", []byte("foo"))
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.ChainErrors, HasLen, 0)
+	c.Check(report.Ok(), Equals, true)
+	c.Check(report.Frames(), Equals, 6)
+	c.Assert(report.Segments, HasLen, 2)
+
+	first := report.Segments[0]
+	c.Check(first.Frames, Equals, 4)
+	c.Check(first.DroppedFrames, Equals, int64(1))
+	c.Check(first.DroppedRatio(), Equals, 0.2)
+	c.Check(first.TimeoutFrames, Equals, 1)
+	c.Check(first.Start, Equals, s.start)
+	c.Check(first.End, Equals, s.start.Add(400*time.Millisecond))
+
+	second := report.Segments[1]
+	c.Check(second.Frames, Equals, 2)
+	c.Check(second.DroppedFrames, Equals, int64(1))
+}
+
+func (s *HermesVerificationSuite) TestTruncatedSegment(c *C) {
+	data := s.segmentData(c, testSegment{
+		FrameIDs: []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	})
+	s.writeSegment(c, "tracking.0000.hermes", data[:len(data)-20])
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, false)
+	c.Assert(report.Segments, HasLen, 1)
+	seg := report.Segments[0]
+	c.Check(seg.HasHeader, Equals, true)
+	c.Check(seg.HasFooter, Equals, false)
+	c.Check(seg.Truncated, Equals, true)
+	c.Check(seg.TruncationError, Matches, "after frame .*")
+}
+
+func (s *HermesVerificationSuite) TestMissingFooter(c *C) {
+	s.writeSegment(c, "tracking.0000.hermes", s.segmentData(c, testSegment{
+		FrameIDs: []int64{0, 1},
+		NoFooter: true,
+	}))
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, false)
+	c.Check(report.Segments[0].HasFooter, Equals, false)
+	c.Check(report.Segments[0].Truncated, Equals, true)
+}
+
+func (s *HermesVerificationSuite) TestBrokenChain(c *C) {
+	s.writeSegment(c, "tracking.0000.hermes", s.segmentData(c, testSegment{
+		Next:     "tracking.0001.hermes",
+		FrameIDs: []int64{0},
+	}))
+	s.writeSegment(c, "tracking.0002.hermes", s.segmentData(c, testSegment{
+		Previous: "tracking.0001.hermes",
+		Next:     "tracking.0003.hermes",
+		FrameIDs: []int64{1},
+	}))
+
+	report, err := VerifyHermesDirectory(s.dir)
+	c.Assert(err, IsNil)
+	c.Check(report.Ok(), Equals, false)
+	c.Check(report.ChainErrors, DeepEquals, []string{
+		"tracking.0000.hermes: next segment is 'tracking.0001.hermes', expected 'tracking.0002.hermes'",
+		"tracking.0002.hermes: previous segment is 'tracking.0001.hermes', expected 'tracking.0000.hermes'",
+		"tracking.0002.hermes: next segment 'tracking.0003.hermes' is missing",
+	})
+}
+
+func (s *HermesVerificationSuite) TestEmptyDirectory(c *C) {
+	_, err := VerifyHermesDirectory(s.dir)
+	c.Check(err, ErrorMatches, "no hermes tracking segment in .*")
+}
@@ -97,16 +97,34 @@ func (n Node) Unlink(link *letopb.TrackingLink) error {
 	return err
 }
 
-func (n Node) StartTracking(request *letopb.StartRequest) error {
+func (n Node) PrepareTracking(request *letopb.StartRequest) error {
 	conn, client, err := n.Connect()
 	if err != nil {
 		return err
 	}
 	defer closeAndLogError(conn)
-	_, err = client.StartTracking(context.Background(), request)
+	_, err = client.PrepareTracking(context.Background(), request)
 	return err
 }
 
+func (n Node) StartTracking(request *letopb.StartRequest) (*letopb.StartResponse, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.StartTracking(context.Background(), request)
+}
+
+func (n Node) ValidateConfiguration(request *letopb.StartRequest) (*letopb.ValidationResponse, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.ValidateConfiguration(context.Background(), request)
+}
+
 func (n Node) StopTracking() error {
 	conn, client, err := n.Connect()
 	if err != nil {
@@ -117,6 +135,35 @@ func (n Node) StopTracking() error {
 	return err
 }
 
+func (n Node) PauseTracking() error {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return err
+	}
+	defer closeAndLogError(conn)
+	_, err = client.PauseTracking(context.Background(), &letopb.Empty{})
+	return err
+}
+
+func (n Node) ResumeTracking() error {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return err
+	}
+	defer closeAndLogError(conn)
+	_, err = client.ResumeTracking(context.Background(), &letopb.Empty{})
+	return err
+}
+
+func (n Node) UpdateConfiguration(update *letopb.ConfigurationUpdate) (*letopb.ConfigurationUpdateResponse, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.UpdateConfiguration(context.Background(), update)
+}
+
 func (n Node) GetStatus() (*letopb.Status, error) {
 	conn, client, err := n.Connect()
 	if err != nil {
@@ -126,6 +173,33 @@ func (n Node) GetStatus() (*letopb.Status, error) {
 	return client.GetStatus(context.Background(), &letopb.Empty{})
 }
 
+// WatchStatus calls onStatus for every status pushed by the node,
+// until ctx is done, the node closes the stream or onStatus returns
+// an error.
+func (n Node) WatchStatus(ctx context.Context, onStatus func(*letopb.Status) error) error {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return err
+	}
+	defer closeAndLogError(conn)
+	stream, err := client.WatchStatus(ctx, &letopb.Empty{})
+	if err != nil {
+		return err
+	}
+	for {
+		status, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := onStatus(status); err != nil {
+			return err
+		}
+	}
+}
+
 func (n Node) GetLastExperimentLog() (*letopb.ExperimentLog, error) {
 	conn, client, err := n.Connect()
 	if err != nil {
@@ -135,6 +209,99 @@ func (n Node) GetLastExperimentLog() (*letopb.ExperimentLog, error) {
 	return client.GetLastExperimentLog(context.Background(), &letopb.Empty{})
 }
 
+func (n Node) ListExperiments() (*letopb.ExperimentLogList, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.ListExperiments(context.Background(), &letopb.Empty{})
+}
+
+func (n Node) GetExperimentLog(id int32) (*letopb.ExperimentLog, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.GetExperimentLog(context.Background(), &letopb.ExperimentLogRequest{Id: id})
+}
+
+func (n Node) ScheduleTracking(request *letopb.ScheduleRequest) (*letopb.ScheduledExperiment, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.ScheduleTracking(context.Background(), request)
+}
+
+func (n Node) ListSchedules() (*letopb.ScheduledExperimentList, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.ListSchedules(context.Background(), &letopb.Empty{})
+}
+
+func (n Node) ListBroadcastClients() (*letopb.BroadcastClientList, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.ListBroadcastClients(context.Background(), &letopb.Empty{})
+}
+
+func (n Node) CancelSchedule(id int32) error {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return err
+	}
+	defer closeAndLogError(conn)
+	_, err = client.CancelSchedule(context.Background(), &letopb.ScheduleCancelRequest{Id: id})
+	return err
+}
+
+func (n Node) SavePreset(preset *letopb.Preset) error {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return err
+	}
+	defer closeAndLogError(conn)
+	_, err = client.SavePreset(context.Background(), preset)
+	return err
+}
+
+func (n Node) ListPresets() (*letopb.PresetList, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.ListPresets(context.Background(), &letopb.Empty{})
+}
+
+func (n Node) GetPreset(name string) (*letopb.Preset, error) {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLogError(conn)
+	return client.GetPreset(context.Background(), &letopb.PresetRequest{Name: name})
+}
+
+func (n Node) DeletePreset(name string) error {
+	conn, client, err := n.Connect()
+	if err != nil {
+		return err
+	}
+	defer closeAndLogError(conn)
+	_, err = client.DeletePreset(context.Background(), &letopb.PresetRequest{Name: name})
+	return err
+}
+
 func NewNodeLister() *NodeLister {
 	res := &NodeLister{}
 	res.load()
@@ -0,0 +1,219 @@
+package leto
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// A ConfigurationError is an error located in a YAML configuration.
+type ConfigurationError struct {
+	Filename     string
+	Line, Column int
+	Message      string
+}
+
+func (e *ConfigurationError) Error() string {
+	if len(e.Filename) > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// A valueCheck checks a value read from a YAML configuration.
+type valueCheck func(v reflect.Value) error
+
+// configurationValueChecks are the checks applied on values of a
+// parsed TrackingConfiguration, by their YAML path.
+var configurationValueChecks = map[string]valueCheck{
+	"camera.fps":             greaterThan(0),
+	"apriltag.quad.decimate": atLeast(1),
+	"apriltag.family":        checkTagFamily,
+	"stream.bitrate":         greaterThan(0),
+}
+
+func greaterThan(min float64) valueCheck {
+	return func(v reflect.Value) error {
+		if asFloat(v) <= min {
+			return fmt.Errorf("should be greater than %g", min)
+		}
+		return nil
+	}
+}
+
+func atLeast(min float64) valueCheck {
+	return func(v reflect.Value) error {
+		if asFloat(v) < min {
+			return fmt.Errorf("should be at least %g", min)
+		}
+		return nil
+	}
+}
+
+func asFloat(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	default:
+		return float64(v.Uint())
+	}
+}
+
+func checkTagFamily(v reflect.Value) error {
+	c := TagDetectionConfiguration{Family: new(string)}
+	*c.Family = v.String()
+	return c.Check()
+}
+
+// parseConfigurationStrict parses content, rejecting unknown keys and
+// invalid values. All errors are reported with their line and column
+// in filename, which could be empty.
+func parseConfigurationStrict(filename string, content []byte) (*TrackingConfiguration, error) {
+	res := &TrackingConfiguration{}
+	root := &yaml3.Node{}
+	if err := yaml3.Unmarshal(content, root); err != nil {
+		if len(filename) > 0 {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		// empty document
+		return res, nil
+	}
+
+	p := &configurationParser{filename: filename}
+	p.check(root.Content[0], reflect.TypeOf(res).Elem(), "")
+	if len(p.errors) > 0 {
+		return nil, errors.Join(p.errors...)
+	}
+
+	if err := root.Decode(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type configurationParser struct {
+	filename string
+	errors   []error
+}
+
+func (p *configurationParser) errorf(node *yaml3.Node, format string, args ...interface{}) {
+	p.errors = append(p.errors, &ConfigurationError{
+		Filename: p.filename,
+		Line:     node.Line,
+		Column:   node.Column,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// check checks that node could be decoded in a value of type t,
+// located at path in the configuration.
+func (p *configurationParser) check(node *yaml3.Node, t reflect.Type, path string) {
+	if node.Kind == yaml3.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml3.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml3.MappingNode:
+		p.checkStruct(node, t, path)
+	case t.Kind() == reflect.Slice && node.Kind == yaml3.SequenceNode:
+		for _, item := range node.Content {
+			p.check(item, t.Elem(), path)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			p.checkValue(node.Content[i], t.Key(), path)
+			p.check(node.Content[i+1], t.Elem(), path)
+		}
+	default:
+		p.checkValue(node, t, path)
+	}
+}
+
+func (p *configurationParser) checkStruct(node *yaml3.Node, t reflect.Type, path string) {
+	fields := yamlFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, ok := fields[key.Value]
+		if ok == false {
+			if len(path) == 0 {
+				p.errorf(key, "unknown field '%s'", key.Value)
+			} else {
+				p.errorf(key, "unknown field '%s' in '%s'", key.Value, path)
+			}
+			continue
+		}
+		p.check(value, field.Type, joinYAMLPath(path, key.Value))
+	}
+}
+
+var yamlErrorLinePrefix = regexp.MustCompile(`^line [0-9]+: `)
+
+// checkValue decodes node as a t value, and applies the value checks
+// of path on it.
+func (p *configurationParser) checkValue(node *yaml3.Node, t reflect.Type, path string) {
+	v := reflect.New(t)
+	if err := node.Decode(v.Interface()); err != nil {
+		var typeError *yaml3.TypeError
+		if errors.As(err, &typeError) == true && len(typeError.Errors) > 0 {
+			p.errorf(node, "%s", yamlErrorLinePrefix.ReplaceAllString(typeError.Errors[0], ""))
+		} else {
+			p.errorf(node, "%s", err)
+		}
+		return
+	}
+	check, ok := configurationValueChecks[path]
+	if ok == false {
+		return
+	}
+	if err := check(v.Elem()); err != nil {
+		p.errorf(node, "invalid %s (%s): %s", path, node.Value, err)
+	}
+}
+
+func joinYAMLPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// yamlFields returns the fields of t by their YAML key.
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	res := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() == false {
+			continue
+		}
+		name := yamlFieldName(f)
+		if name == "-" {
+			continue
+		}
+		res[name] = f
+	}
+	return res
+}
+
+// yamlFieldName returns the YAML key of f, or "-" if it is ignored.
+func yamlFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if len(name) == 0 {
+		name = strings.ToLower(f.Name)
+	}
+	return name
+}
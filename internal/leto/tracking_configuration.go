@@ -1,10 +1,13 @@
 package leto
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
+	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -40,7 +43,7 @@ func MergeConfiguration(from, to interface{}) error {
 		fromField := vFrom.FieldByName(tField.Name)
 		toField := vTo.FieldByName(tField.Name)
 
-		if fromField.Elem().CanSet() == false {
+		if fromField.CanSet() == false {
 			continue
 		}
 
@@ -48,6 +51,12 @@ func MergeConfiguration(from, to interface{}) error {
 			continue
 		}
 
+		// partial configurations, like presets, may not have the
+		// field set yet.
+		if fromField.IsNil() {
+			fromField.Set(reflect.New(tField.Type.Elem()))
+		}
+
 		fromField.Elem().Set(toField.Elem())
 	}
 
@@ -116,6 +125,34 @@ func (from *TagDetectionConfiguration) Merge(to *TagDetectionConfiguration) erro
 	return MergeConfiguration(from, to)
 }
 
+// TagFamilies lists the tag families supported by artemis.
+var TagFamilies = []string{
+	"16h5",
+	"25h9",
+	"36h10",
+	"36h11",
+	"36ARTag",
+	"Circle21h7",
+	"Circle49h12",
+	"Custom48h12",
+	"Standard41h12",
+	"Standard52h13",
+}
+
+// Check returns an error if Family is not a tag family supported by
+// artemis. An empty family disables the tag detection.
+func (c *TagDetectionConfiguration) Check() error {
+	if len(*c.Family) == 0 {
+		return nil
+	}
+	for _, f := range TagFamilies {
+		if strings.EqualFold(f, *c.Family) == true {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown tag family '%s': valid values are %s", *c.Family, strings.Join(TagFamilies, ", "))
+}
+
 type CameraConfiguration struct {
 	StrobeDelay    *time.Duration `long:"strobe-delay" description:"delay of the strobe signal (recommended:0us)" yaml:"strobe-delay"`
 	StrobeDuration *time.Duration `long:"strobe-duration" description:"duration of the strobe signal (recommended:1500us)" yaml:"strobe-duration"`
@@ -169,6 +206,185 @@ func (from *StreamConfiguration) Merge(to *StreamConfiguration) error {
 	return MergeConfiguration(from, to)
 }
 
+type OutputConfiguration struct {
+	Period          *time.Duration `long:"segment-period" description:"maximal duration of hermes and video segments (recommended:2h)" yaml:"period"`
+	HermesMaxSizeMB *int           `long:"hermes-segment-max-size" description:"maximal size in MB of a hermes tracking segment, unlimited if 0" yaml:"hermes-max-size-mb"`
+	VideoMaxSizeMB  *int           `long:"video-segment-max-size" description:"maximal size in MB of a video segment, unlimited if 0" yaml:"video-max-size-mb"`
+}
+
+func RecommendedOutputConfiguration() OutputConfiguration {
+	res := OutputConfiguration{
+		Period:          new(time.Duration),
+		HermesMaxSizeMB: new(int),
+		VideoMaxSizeMB:  new(int),
+	}
+	*res.Period = 2 * time.Hour
+	*res.HermesMaxSizeMB = 0
+	*res.VideoMaxSizeMB = 0
+	return res
+}
+
+func (from *OutputConfiguration) Merge(to *OutputConfiguration) error {
+	return MergeConfiguration(from, to)
+}
+
+// Check returns an error if segments would be rotated too often.
+func (c *OutputConfiguration) Check() error {
+	const minPeriod = time.Minute
+	const minSizeMB = 1
+	if *c.Period < minPeriod {
+		return fmt.Errorf("segment period (%s) should be at least %s", *c.Period, minPeriod)
+	}
+	if *c.HermesMaxSizeMB != 0 && *c.HermesMaxSizeMB < minSizeMB {
+		return fmt.Errorf("hermes segment maximal size (%d MB) should be 0 or at least %d MB", *c.HermesMaxSizeMB, minSizeMB)
+	}
+	if *c.VideoMaxSizeMB != 0 && *c.VideoMaxSizeMB < minSizeMB {
+		return fmt.Errorf("video segment maximal size (%d MB) should be 0 or at least %d MB", *c.VideoMaxSizeMB, minSizeMB)
+	}
+	return nil
+}
+
+type RotationConfiguration struct {
+	At     *string        `long:"rotate-at" description:"wall-clock time (HH:MM) at which the experiment output is rotated in a new directory, disabled if empty" yaml:"at"`
+	Period *time.Duration `long:"rotation-period" description:"period between two experiment directory rotations (recommended:24h)" yaml:"period"`
+}
+
+func RecommendedRotationConfiguration() RotationConfiguration {
+	res := RotationConfiguration{
+		At:     new(string),
+		Period: new(time.Duration),
+	}
+	*res.At = ""
+	*res.Period = 24 * time.Hour
+	return res
+}
+
+func (from *RotationConfiguration) Merge(to *RotationConfiguration) error {
+	return MergeConfiguration(from, to)
+}
+
+const (
+	BroadcastDropNewest = "drop-newest"
+	BroadcastDropOldest = "drop-oldest"
+	BroadcastDisconnect = "disconnect"
+)
+
+type BroadcastConfiguration struct {
+	Policy              *string `long:"broadcast-policy" description:"what to do when a broadcast client is too slow: drop-newest, drop-oldest or disconnect (recommended:drop-newest)" yaml:"policy"`
+	MaxConsecutiveDrops *int    `long:"broadcast-max-drops" description:"number of consecutive dropped frames before a slow broadcast client is disconnected, with the disconnect policy (recommended:100)" yaml:"max-consecutive-drops"`
+}
+
+func RecommendedBroadcastConfiguration() BroadcastConfiguration {
+	res := BroadcastConfiguration{
+		Policy:              new(string),
+		MaxConsecutiveDrops: new(int),
+	}
+	*res.Policy = BroadcastDropNewest
+	*res.MaxConsecutiveDrops = 100
+	return res
+}
+
+func (from *BroadcastConfiguration) Merge(to *BroadcastConfiguration) error {
+	return MergeConfiguration(from, to)
+}
+
+// Check returns an error if the slow client policy is invalid.
+func (c *BroadcastConfiguration) Check() error {
+	switch *c.Policy {
+	case BroadcastDropNewest, BroadcastDropOldest, BroadcastDisconnect:
+	default:
+		return fmt.Errorf("invalid broadcast policy '%s': valid values are %s, %s or %s",
+			*c.Policy, BroadcastDropNewest, BroadcastDropOldest, BroadcastDisconnect)
+	}
+	if *c.MaxConsecutiveDrops < 1 {
+		return fmt.Errorf("broadcast maximal consecutive drops (%d) should be at least 1", *c.MaxConsecutiveDrops)
+	}
+	return nil
+}
+
+// A SynchronizationConfiguration sets the tolerance on the clock
+// synchronization of slave nodes with the master.
+type SynchronizationConfiguration struct {
+	MaxClockDrift *time.Duration `long:"max-clock-drift" description:"maximal drift of a slave clock offset before raising an alarm (recommended:5ms)" yaml:"max-clock-drift"`
+}
+
+func RecommendedSynchronizationConfiguration() SynchronizationConfiguration {
+	res := SynchronizationConfiguration{
+		MaxClockDrift: new(time.Duration),
+	}
+	*res.MaxClockDrift = 5 * time.Millisecond
+	return res
+}
+
+func (from *SynchronizationConfiguration) Merge(to *SynchronizationConfiguration) error {
+	return MergeConfiguration(from, to)
+}
+
+// Check returns an error if the maximal clock drift is not positive.
+func (c *SynchronizationConfiguration) Check() error {
+	if *c.MaxClockDrift <= 0 {
+		return fmt.Errorf("maximal clock drift (%s) should be positive", *c.MaxClockDrift)
+	}
+	return nil
+}
+
+const (
+	SlaveFailureContinue = "continue"
+	SlaveFailureAbort    = "abort"
+)
+
+// A SlaveSupervisionConfiguration sets how the master supervises its
+// slaves during an experiment.
+type SlaveSupervisionConfiguration struct {
+	Period      *time.Duration `long:"slave-poll-period" description:"period between two slave status polls (recommended:5s)" yaml:"period"`
+	GiveUpAfter *time.Duration `long:"slave-give-up-after" description:"time after which a slave which is not tracking is given up (recommended:2m)" yaml:"give-up-after"`
+	OnFailure   *string        `long:"slave-on-failure" description:"what to do when a slave is given up: continue or abort (recommended:continue)" yaml:"on-failure"`
+}
+
+func RecommendedSlaveSupervisionConfiguration() SlaveSupervisionConfiguration {
+	res := SlaveSupervisionConfiguration{
+		Period:      new(time.Duration),
+		GiveUpAfter: new(time.Duration),
+		OnFailure:   new(string),
+	}
+	*res.Period = 5 * time.Second
+	*res.GiveUpAfter = 2 * time.Minute
+	*res.OnFailure = SlaveFailureContinue
+	return res
+}
+
+func (from *SlaveSupervisionConfiguration) Merge(to *SlaveSupervisionConfiguration) error {
+	return MergeConfiguration(from, to)
+}
+
+// Check returns an error if the supervision periods or failure policy
+// are invalid.
+func (c *SlaveSupervisionConfiguration) Check() error {
+	if *c.Period <= 0 {
+		return fmt.Errorf("slave poll period (%s) should be positive", *c.Period)
+	}
+	if *c.GiveUpAfter < *c.Period {
+		return fmt.Errorf("slave give up delay (%s) should be at least the poll period (%s)", *c.GiveUpAfter, *c.Period)
+	}
+	switch *c.OnFailure {
+	case SlaveFailureContinue, SlaveFailureAbort:
+	default:
+		return fmt.Errorf("invalid slave failure policy '%s': valid values are %s or %s",
+			*c.OnFailure, SlaveFailureContinue, SlaveFailureAbort)
+	}
+	return nil
+}
+
+// A SinkConfiguration describes an additional consumer of the frame
+// readouts of an experiment. Path and Address are used depending on
+// Type.
+type SinkConfiguration struct {
+	Name    string `yaml:"name,omitempty"`
+	Type    string `yaml:"type"`
+	Path    string `yaml:"path,omitempty"`
+	Address string `yaml:"address,omitempty"`
+}
+
 type LoadBalancing struct {
 	SelfUUID      string            `yaml:"self-UUID"`
 	UUIDs         map[string]string `yaml:"UUIDs"`
@@ -177,17 +393,24 @@ type LoadBalancing struct {
 }
 
 type TrackingConfiguration struct {
-	ExperimentName      string                    `short:"e" long:"experiment" description:"Name of the experiment to run" yaml:"experiment"`
-	LegacyMode          *bool                     `long:"legacy-mode" description:"Produces a legacy mode data output" yaml:"legacy-mode"`
-	NewAntOutputROISize *int                      `long:"new-ant-size" description:"Size of the image when a new ant is found (recommended:600)" yaml:"new-ant-roi"`
-	NewAntRenewPeriod   *time.Duration            `long:"image-renew-period" description:"Period to renew ant snapshot (recommended:2h)" yaml:"image-renew-period"`
-	Stream              StreamConfiguration       `yaml:"stream"`
-	Camera              CameraConfiguration       `yaml:"camera"`
-	Detection           TagDetectionConfiguration `yaml:"apriltag"`
-	Highlights          *[]int                    `yaml:"highlights"`
-	Loads               *LoadBalancing            `yaml:"load-balancing"`
-	Threads             *int                      `yaml:"threads"`
-	RestartOnReboot     bool                      `yaml:"restart-on-reboot"`
+	Base                ConfigurationBases            `yaml:"base,omitempty"`
+	ExperimentName      string                        `short:"e" long:"experiment" description:"Name of the experiment to run" yaml:"experiment"`
+	LegacyMode          *bool                         `long:"legacy-mode" description:"Produces a legacy mode data output" yaml:"legacy-mode"`
+	NewAntOutputROISize *int                          `long:"new-ant-size" description:"Size of the image when a new ant is found (recommended:600)" yaml:"new-ant-roi"`
+	NewAntRenewPeriod   *time.Duration                `long:"image-renew-period" description:"Period to renew ant snapshot (recommended:2h)" yaml:"image-renew-period"`
+	Stream              StreamConfiguration           `yaml:"stream"`
+	Camera              CameraConfiguration           `yaml:"camera"`
+	Detection           TagDetectionConfiguration     `yaml:"apriltag"`
+	Output              OutputConfiguration           `yaml:"output"`
+	Rotation            RotationConfiguration         `yaml:"rotation"`
+	Broadcast           BroadcastConfiguration        `yaml:"broadcast"`
+	Synchronization     SynchronizationConfiguration  `yaml:"synchronization"`
+	SlaveSupervision    SlaveSupervisionConfiguration `yaml:"slave-supervision"`
+	Sinks               *[]SinkConfiguration          `yaml:"sinks"`
+	Highlights          *[]int                        `yaml:"highlights"`
+	Loads               *LoadBalancing                `yaml:"load-balancing"`
+	Threads             *int                          `yaml:"threads"`
+	RestartOnReboot     bool                          `yaml:"restart-on-reboot"`
 }
 
 func RecommendedTrackingConfiguration() TrackingConfiguration {
@@ -198,6 +421,12 @@ func RecommendedTrackingConfiguration() TrackingConfiguration {
 		Stream:              RecommendedStreamConfiguration(),
 		Camera:              RecommendedCameraConfiguration(),
 		Detection:           RecommendedDetectionConfig(),
+		Output:              RecommendedOutputConfiguration(),
+		Rotation:            RecommendedRotationConfiguration(),
+		Broadcast:           RecommendedBroadcastConfiguration(),
+		Synchronization:     RecommendedSynchronizationConfiguration(),
+		SlaveSupervision:    RecommendedSlaveSupervisionConfiguration(),
+		Sinks:               &([]SinkConfiguration{}),
 		Highlights:          &([]int{}),
 		Threads:             new(int),
 	}
@@ -218,10 +447,28 @@ func (from *TrackingConfiguration) Merge(to *TrackingConfiguration) error {
 	if err := from.Detection.Merge(&to.Detection); err != nil {
 		return err
 	}
+	if err := from.Output.Merge(&to.Output); err != nil {
+		return err
+	}
+	if err := from.Rotation.Merge(&to.Rotation); err != nil {
+		return err
+	}
+	if err := from.Broadcast.Merge(&to.Broadcast); err != nil {
+		return err
+	}
+	if err := from.Synchronization.Merge(&to.Synchronization); err != nil {
+		return err
+	}
+	if err := from.SlaveSupervision.Merge(&to.SlaveSupervision); err != nil {
+		return err
+	}
 
 	if len(to.ExperimentName) > 0 {
 		from.ExperimentName = to.ExperimentName
 	}
+	if to.RestartOnReboot == true {
+		from.RestartOnReboot = true
+	}
 	if from.Loads == nil && to.Loads != nil {
 		from.Loads = &LoadBalancing{}
 		*from.Loads = *to.Loads
@@ -254,19 +501,21 @@ func (c *TrackingConfiguration) CheckAllFieldAreSet() error {
 	return CheckNoNilField(reflect.ValueOf(*c))
 }
 
+// ParseConfiguration parses a YAML configuration. Unknown keys and
+// out of range values are rejected, errors report their line and
+// column.
 func ParseConfiguration(content []byte) (*TrackingConfiguration, error) {
-	res := &TrackingConfiguration{}
-	err := yaml.Unmarshal(content, res)
-
-	return res, err
+	return parseConfigurationStrict("", content)
 }
 
+// ReadConfiguration reads and parses the YAML configuration in
+// filename, as ParseConfiguration.
 func ReadConfiguration(filename string) (*TrackingConfiguration, error) {
 	content, err := ioutil.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("Could not read '%s': %s", filename, err)
+		return nil, fmt.Errorf("Could not read '%s': %w", filename, err)
 	}
-	return ParseConfiguration(content)
+	return parseConfigurationStrict(filename, content)
 }
 
 func (c *TrackingConfiguration) Yaml() ([]byte, error) {
@@ -291,17 +540,37 @@ func (c *TrackingConfiguration) WriteConfiguration(filename string) error {
 	return nil
 }
 
-func LoadDefaultConfig() *TrackingConfiguration {
+// LoadDefaultConfig returns the recommended configuration merged with
+// the system one. It returns an error if the system configuration
+// exists but is invalid.
+func LoadDefaultConfig() (*TrackingConfiguration, error) {
+	res, _, err := LoadDefaultConfigWithProvenance()
+	return res, err
+}
+
+// LoadDefaultConfigWithProvenance returns the recommended
+// configuration merged with the system one, and which of them
+// supplied each field. The recommended configuration is only used
+// alone if the system configuration does not exist.
+func LoadDefaultConfigWithProvenance() (*TrackingConfiguration, ConfigurationProvenance, error) {
+	return loadDefaultConfig(SystemConfigPath)
+}
+
+func loadDefaultConfig(systemConfigPath string) (*TrackingConfiguration, ConfigurationProvenance, error) {
 	res := RecommendedTrackingConfiguration()
-	systemConfig, err := ReadConfiguration("/etc/default/leto.yml")
+	provenance := NewConfigurationProvenance(RecommendedLayer)
+	systemConfig, err := ReadConfiguration(systemConfigPath)
+	if errors.Is(err, os.ErrNotExist) == true {
+		return &res, provenance, nil
+	}
 	if err != nil {
-		return &res
+		return nil, nil, fmt.Errorf("invalid system configuration: %w", err)
 	}
 
-	err = res.Merge(systemConfig)
-	if err != nil {
-		res = RecommendedTrackingConfiguration()
+	if err := res.Merge(systemConfig); err != nil {
+		return nil, nil, fmt.Errorf("could not merge system configuration '%s': %w", systemConfigPath, err)
 	}
+	provenance.Record(SystemLayer, systemConfig)
 
-	return &res
+	return &res, provenance, nil
 }
@@ -70,6 +70,17 @@ func (s *ConfigurationSuite) TestCanBeMerged(c *C) {
 	*to.Detection.Quad.MinBWDiff = 120
 	*expected.Detection.Quad.MinBWDiff = 120
 
+	to.Output.HermesMaxSizeMB = new(int)
+	*to.Output.HermesMaxSizeMB = 512
+	*expected.Output.HermesMaxSizeMB = 512
+
+	to.Rotation.At = new(string)
+	*to.Rotation.At = "00:00"
+	*expected.Rotation.At = "00:00"
+
+	to.RestartOnReboot = true
+	expected.RestartOnReboot = true
+
 	to.Loads = &LoadBalancing{"single-node", map[string]string{"localhost": "single-node"}, map[int]string{0: "single-node"}, 640, 480}
 	expected.Loads = &LoadBalancing{"single-node", map[string]string{"localhost": "single-node"}, map[int]string{0: "single-node"}, 640, 480}
 
@@ -78,6 +89,32 @@ func (s *ConfigurationSuite) TestCanBeMerged(c *C) {
 
 }
 
+func (s *ConfigurationSuite) TestCanBeMergedInPartialConfiguration(c *C) {
+	from, err := ParseConfiguration([]byte(`
+experiment: preset
+apriltag:
+  family: 36h11
+`))
+	c.Assert(err, IsNil)
+
+	to := &TrackingConfiguration{}
+	to.Camera.FPS = new(float64)
+	*to.Camera.FPS = 6.0
+	to.Detection.Quad.Decimate = new(float64)
+	*to.Detection.Quad.Decimate = 2.0
+
+	c.Assert(from.Merge(to), IsNil)
+	c.Check(from.ExperimentName, Equals, "preset")
+	c.Assert(from.Camera.FPS, NotNil)
+	c.Check(*from.Camera.FPS, Equals, 6.0)
+	c.Assert(from.Detection.Quad.Decimate, NotNil)
+	c.Check(*from.Detection.Quad.Decimate, Equals, 2.0)
+	c.Check(*from.Detection.Family, Equals, "36h11")
+	// it must not alias the merged configuration
+	*to.Camera.FPS = 8.0
+	c.Check(*from.Camera.FPS, Equals, 6.0)
+}
+
 func (s *ConfigurationSuite) TestYAMLParsing(c *C) {
 
 	expected := RecommendedTrackingConfiguration()
@@ -119,6 +156,23 @@ apriltag:
     max-line-mean-square-error: 10
     min-black-white-diff: 50
     deglitch: false
+output:
+  period: 2h
+  hermes-max-size-mb: 0
+  video-max-size-mb: 0
+rotation:
+  at: ""
+  period: 24h
+broadcast:
+  policy: drop-newest
+  max-consecutive-drops: 100
+synchronization:
+  max-clock-drift: 5ms
+slave-supervision:
+  period: 5s
+  give-up-after: 2m0s
+  on-failure: continue
+sinks: []
 highlights:
   - 1
   - 42
@@ -204,8 +258,7 @@ func (s *ConfigurationSuite) TestConfigurationIO(c *C) {
 	_, err = ReadConfiguration(unexistConfigPath)
 	c.Check(err, ErrorMatches, `Could not read '.*': open .*`)
 	_, err = ReadConfiguration(badConfigPath)
-	c.Check(err, ErrorMatches, `yaml: unmarshal errors:
-  line 1:.*`)
+	c.Check(err, ErrorMatches, `.*/bad-config.yml:1:1: cannot unmarshal !!str .* into leto.TrackingConfiguration`)
 
 	err = config.WriteConfiguration(unexistConfigPath)
 	c.Check(err, IsNil)
@@ -215,6 +268,90 @@ func (s *ConfigurationSuite) TestConfigurationIO(c *C) {
 
 }
 
+func (s *ConfigurationSuite) TestStrictParsing(c *C) {
+	testdata := []struct {
+		Content  string
+		Expected string
+	}{
+		{
+			`camera:
+  strobe-duraton: 1500us
+`,
+			`line 2, column 3: unknown field 'strobe-duraton' in 'camera'`,
+		},
+		{
+			`experiment: foo
+apriltag:
+  quad:
+    min-black-white-dif: 50
+`,
+			`line 4, column 5: unknown field 'min-black-white-dif' in 'apriltag.quad'`,
+		},
+		{
+			`expriment: foo`,
+			`line 1, column 1: unknown field 'expriment'`,
+		},
+		{
+			`camera:
+  fps: 0
+stream:
+  bitrate: -12
+apriltag:
+  family: 36h12
+  quad:
+    decimate: 0.5
+`,
+			`line 2, column 8: invalid camera.fps \(0\): should be greater than 0
+line 4, column 12: invalid stream.bitrate \(-12\): should be greater than 0
+line 6, column 11: invalid apriltag.family \(36h12\): unknown tag family '36h12': .*
+line 8, column 15: invalid apriltag.quad.decimate \(0.5\): should be at least 1`,
+		},
+		{
+			`camera:
+  fps: fast
+`,
+			"line 2, column 8: cannot unmarshal !!str `fast` into float64",
+		},
+		{
+			`sinks:
+  - type: csv
+    pth: foo.csv
+`,
+			`line 3, column 5: unknown field 'pth' in 'sinks'`,
+		},
+	}
+
+	for _, d := range testdata {
+		_, err := ParseConfiguration([]byte(d.Content))
+		c.Check(err, ErrorMatches, d.Expected, Commentf("parsing %s", d.Content))
+	}
+
+	filename := filepath.Join(s.testDir, "typo.yml")
+	c.Assert(ioutil.WriteFile(filename, []byte("threads: 2\nthread: 4\n"), 0644), IsNil)
+	_, err := ReadConfiguration(filename)
+	c.Check(err, ErrorMatches, `.*/typo.yml:2:1: unknown field 'thread'`)
+}
+
+func (s *ConfigurationSuite) TestStrictParsingAcceptsGeneratedConfiguration(c *C) {
+	config := RecommendedTrackingConfiguration()
+	config.ExperimentName = "foo"
+	config.Loads = &LoadBalancing{
+		SelfUUID:     "abcd",
+		UUIDs:        map[string]string{"localhost": "abcd"},
+		Assignements: map[int]string{0: "abcd"},
+		Width:        1920,
+		Height:       1080,
+	}
+	*config.Sinks = []SinkConfiguration{{Type: "csv", Path: "out.csv"}}
+	*config.Highlights = []int{1, 2}
+
+	data, err := config.Yaml()
+	c.Assert(err, IsNil)
+	parsed, err := ParseConfiguration(data)
+	c.Assert(err, IsNil)
+	c.Check(parsed, DeepEquals, &config)
+}
+
 func (s *ConfigurationSuite) TestNoNilFieldCheck(c *C) {
 	testdata := []struct {
 		Data     interface{}
@@ -240,3 +377,86 @@ func (s *ConfigurationSuite) TestNoNilFieldCheck(c *C) {
 	}
 
 }
+
+func (s *ConfigurationSuite) TestTagDetectionConfigurationCheck(c *C) {
+	config := RecommendedDetectionConfig()
+	c.Check(config.Check(), IsNil)
+
+	*config.Family = "36h11"
+	c.Check(config.Check(), IsNil)
+
+	*config.Family = "standard41H12"
+	c.Check(config.Check(), IsNil)
+
+	*config.Family = "36HARTag"
+	c.Check(config.Check(), ErrorMatches, `unknown tag family '36HARTag': valid values are 16h5, 25h9, .*, Standard52h13`)
+}
+
+func (s *ConfigurationSuite) TestOutputConfigurationCheck(c *C) {
+	config := RecommendedOutputConfiguration()
+	c.Check(config.Check(), IsNil)
+
+	*config.Period = 10 * time.Second
+	c.Check(config.Check(), ErrorMatches, `segment period \(10s\) should be at least 1m0s`)
+	*config.Period = time.Hour
+
+	*config.HermesMaxSizeMB = -1
+	c.Check(config.Check(), ErrorMatches, `hermes segment maximal size \(-1 MB\) should be 0 or at least 1 MB`)
+	*config.HermesMaxSizeMB = 0
+
+	*config.VideoMaxSizeMB = -4
+	c.Check(config.Check(), ErrorMatches, `video segment maximal size \(-4 MB\) should be 0 or at least 1 MB`)
+}
+
+func (s *ConfigurationSuite) TestBroadcastConfigurationCheck(c *C) {
+	config := RecommendedBroadcastConfiguration()
+	c.Check(config.Check(), IsNil)
+
+	*config.Policy = "drop-all"
+	c.Check(config.Check(), ErrorMatches, `invalid broadcast policy 'drop-all': valid values are drop-newest, drop-oldest or disconnect`)
+	*config.Policy = BroadcastDisconnect
+
+	*config.MaxConsecutiveDrops = 0
+	c.Check(config.Check(), ErrorMatches, `broadcast maximal consecutive drops \(0\) should be at least 1`)
+}
+
+func (s *ConfigurationSuite) TestSynchronizationConfigurationCheck(c *C) {
+	config := RecommendedSynchronizationConfiguration()
+	c.Check(config.Check(), IsNil)
+
+	*config.MaxClockDrift = 0
+	c.Check(config.Check(), ErrorMatches, `maximal clock drift \(0s\) should be positive`)
+}
+
+func (s *ConfigurationSuite) TestSlaveSupervisionConfigurationCheck(c *C) {
+	config := RecommendedSlaveSupervisionConfiguration()
+	c.Check(config.Check(), IsNil)
+
+	*config.OnFailure = "retry"
+	c.Check(config.Check(), ErrorMatches, `invalid slave failure policy 'retry': valid values are continue or abort`)
+	*config.OnFailure = SlaveFailureAbort
+
+	*config.GiveUpAfter = time.Second
+	c.Check(config.Check(), ErrorMatches, `slave give up delay \(1s\) should be at least the poll period \(5s\)`)
+
+	*config.Period = 0
+	c.Check(config.Check(), ErrorMatches, `slave poll period \(0s\) should be positive`)
+}
+
+func (s *ConfigurationSuite) TestDefaultConfigReportsInvalidSystemConfiguration(c *C) {
+	config, provenance, err := loadDefaultConfig(filepath.Join(s.testDir, "does-not-exist.yml"))
+	c.Assert(err, IsNil)
+	c.Check(*config, DeepEquals, RecommendedTrackingConfiguration())
+	c.Check(provenance["threads"], Equals, RecommendedLayer)
+
+	filename := filepath.Join(s.testDir, "system.yml")
+	c.Assert(ioutil.WriteFile(filename, []byte("threads: 2\n"), 0644), IsNil)
+	config, provenance, err = loadDefaultConfig(filename)
+	c.Assert(err, IsNil)
+	c.Check(*config.Threads, Equals, 2)
+	c.Check(provenance["threads"], Equals, SystemLayer)
+
+	c.Assert(ioutil.WriteFile(filename, []byte("threads: 2\nthread: 4\n"), 0644), IsNil)
+	_, _, err = loadDefaultConfig(filename)
+	c.Check(err, ErrorMatches, `invalid system configuration: .*/system.yml:2:1: unknown field 'thread'`)
+}
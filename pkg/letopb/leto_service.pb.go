@@ -59,12 +59,15 @@ func (*Empty) Descriptor() ([]byte, []int) {
 	return file_leto_service_proto_rawDescGZIP(), []int{0}
 }
 
+// starts an experiment with the configuration in yaml_configuration,
+// merged over the preset named preset, if any.
 type StartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	YamlConfiguration string `protobuf:"bytes,1,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	Preset            string `protobuf:"bytes,2,opt,name=preset,proto3" json:"preset,omitempty"`
 }
 
 func (x *StartRequest) Reset() {
@@ -106,18 +109,25 @@ func (x *StartRequest) GetYamlConfiguration() string {
 	return ""
 }
 
-type ExperimentStatus struct {
+func (x *StartRequest) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+// the experiment started on one node of a cluster.
+type NodeResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Since             *timestamp.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
-	ExperimentDir     string               `protobuf:"bytes,2,opt,name=experiment_dir,json=experimentDir,proto3" json:"experiment_dir,omitempty"`
-	YamlConfiguration string               `protobuf:"bytes,3,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	Node          string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	ExperimentDir string `protobuf:"bytes,2,opt,name=experiment_dir,json=experimentDir,proto3" json:"experiment_dir,omitempty"`
 }
 
-func (x *ExperimentStatus) Reset() {
-	*x = ExperimentStatus{}
+func (x *NodeResult) Reset() {
+	*x = NodeResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_leto_service_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -125,13 +135,13 @@ func (x *ExperimentStatus) Reset() {
 	}
 }
 
-func (x *ExperimentStatus) String() string {
+func (x *NodeResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExperimentStatus) ProtoMessage() {}
+func (*NodeResult) ProtoMessage() {}
 
-func (x *ExperimentStatus) ProtoReflect() protoreflect.Message {
+func (x *NodeResult) ProtoReflect() protoreflect.Message {
 	mi := &file_leto_service_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -143,62 +153,100 @@ func (x *ExperimentStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExperimentStatus.ProtoReflect.Descriptor instead.
-func (*ExperimentStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use NodeResult.ProtoReflect.Descriptor instead.
+func (*NodeResult) Descriptor() ([]byte, []int) {
 	return file_leto_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ExperimentStatus) GetSince() *timestamp.Timestamp {
+func (x *NodeResult) GetNode() string {
 	if x != nil {
-		return x.Since
+		return x.Node
 	}
-	return nil
+	return ""
 }
 
-func (x *ExperimentStatus) GetExperimentDir() string {
+func (x *NodeResult) GetExperimentDir() string {
 	if x != nil {
 		return x.ExperimentDir
 	}
 	return ""
 }
 
-func (x *ExperimentStatus) GetYamlConfiguration() string {
+type StartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes []*NodeResult `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *StartResponse) Reset() {
+	*x = StartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartResponse) ProtoMessage() {}
+
+func (x *StartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartResponse.ProtoReflect.Descriptor instead.
+func (*StartResponse) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartResponse) GetNodes() []*NodeResult {
 	if x != nil {
-		return x.YamlConfiguration
+		return x.Nodes
 	}
-	return ""
+	return nil
 }
 
-type Status struct {
+// the outcome of a configuration validation on a node.
+type ValidationResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Master         string            `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
-	Slaves         []string          `protobuf:"bytes,2,rep,name=slaves,proto3" json:"slaves,omitempty"`
-	Experiment     *ExperimentStatus `protobuf:"bytes,3,opt,name=experiment,proto3" json:"experiment,omitempty"`
-	TotalBytes     int64             `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
-	FreeBytes      int64             `protobuf:"varint,5,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"`
-	BytesPerSecond int64             `protobuf:"varint,6,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	YamlConfiguration string   `protobuf:"bytes,1,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	ArtemisCommand    []string `protobuf:"bytes,2,rep,name=artemis_command,json=artemisCommand,proto3" json:"artemis_command,omitempty"`
+	ExperimentDir     string   `protobuf:"bytes,3,opt,name=experiment_dir,json=experimentDir,proto3" json:"experiment_dir,omitempty"`
 }
 
-func (x *Status) Reset() {
-	*x = Status{}
+func (x *ValidationResponse) Reset() {
+	*x = ValidationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leto_service_proto_msgTypes[3]
+		mi := &file_leto_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Status) String() string {
+func (x *ValidationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Status) ProtoMessage() {}
+func (*ValidationResponse) ProtoMessage() {}
 
-func (x *Status) ProtoReflect() protoreflect.Message {
-	mi := &file_leto_service_proto_msgTypes[3]
+func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -209,85 +257,110 @@ func (x *Status) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Status.ProtoReflect.Descriptor instead.
-func (*Status) Descriptor() ([]byte, []int) {
-	return file_leto_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
+func (*ValidationResponse) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Status) GetMaster() string {
+func (x *ValidationResponse) GetYamlConfiguration() string {
 	if x != nil {
-		return x.Master
+		return x.YamlConfiguration
 	}
 	return ""
 }
 
-func (x *Status) GetSlaves() []string {
+func (x *ValidationResponse) GetArtemisCommand() []string {
 	if x != nil {
-		return x.Slaves
+		return x.ArtemisCommand
 	}
 	return nil
 }
 
-func (x *Status) GetExperiment() *ExperimentStatus {
+func (x *ValidationResponse) GetExperimentDir() string {
 	if x != nil {
-		return x.Experiment
+		return x.ExperimentDir
 	}
-	return nil
+	return ""
 }
 
-func (x *Status) GetTotalBytes() int64 {
-	if x != nil {
-		return x.TotalBytes
+// changes the configuration of the running experiment with the
+// fields set in yaml_configuration.
+type ConfigurationUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	YamlConfiguration string `protobuf:"bytes,1,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+}
+
+func (x *ConfigurationUpdate) Reset() {
+	*x = ConfigurationUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Status) GetFreeBytes() int64 {
-	if x != nil {
-		return x.FreeBytes
+func (x *ConfigurationUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationUpdate) ProtoMessage() {}
+
+func (x *ConfigurationUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Status) GetBytesPerSecond() int64 {
+// Deprecated: Use ConfigurationUpdate.ProtoReflect.Descriptor instead.
+func (*ConfigurationUpdate) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConfigurationUpdate) GetYamlConfiguration() string {
 	if x != nil {
-		return x.BytesPerSecond
+		return x.YamlConfiguration
 	}
-	return 0
+	return ""
 }
 
-type ExperimentLog struct {
+// a field of the configuration changed by a ConfigurationUpdate, by
+// its YAML path.
+type ConfigurationChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Log               string               `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
-	Stderr            string               `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
-	ExperimentDir     string               `protobuf:"bytes,3,opt,name=experiment_dir,json=experimentDir,proto3" json:"experiment_dir,omitempty"`
-	Start             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
-	End               *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
-	YamlConfiguration string               `protobuf:"bytes,6,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
-	HasError          bool                 `protobuf:"varint,7,opt,name=has_error,json=hasError,proto3" json:"has_error,omitempty"`
-	Error             string               `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Path     string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Previous string `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	Value    string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *ExperimentLog) Reset() {
-	*x = ExperimentLog{}
+func (x *ConfigurationChange) Reset() {
+	*x = ConfigurationChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leto_service_proto_msgTypes[4]
+		mi := &file_leto_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExperimentLog) String() string {
+func (x *ConfigurationChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExperimentLog) ProtoMessage() {}
+func (*ConfigurationChange) ProtoMessage() {}
 
-func (x *ExperimentLog) ProtoReflect() protoreflect.Message {
-	mi := &file_leto_service_proto_msgTypes[4]
+func (x *ConfigurationChange) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,93 +371,110 @@ func (x *ExperimentLog) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExperimentLog.ProtoReflect.Descriptor instead.
-func (*ExperimentLog) Descriptor() ([]byte, []int) {
-	return file_leto_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ConfigurationChange.ProtoReflect.Descriptor instead.
+func (*ConfigurationChange) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ExperimentLog) GetLog() string {
+func (x *ConfigurationChange) GetPath() string {
 	if x != nil {
-		return x.Log
+		return x.Path
 	}
 	return ""
 }
 
-func (x *ExperimentLog) GetStderr() string {
+func (x *ConfigurationChange) GetPrevious() string {
 	if x != nil {
-		return x.Stderr
+		return x.Previous
 	}
 	return ""
 }
 
-func (x *ExperimentLog) GetExperimentDir() string {
+func (x *ConfigurationChange) GetValue() string {
 	if x != nil {
-		return x.ExperimentDir
+		return x.Value
 	}
 	return ""
 }
 
-func (x *ExperimentLog) GetStart() *timestamp.Timestamp {
-	if x != nil {
-		return x.Start
-	}
-	return nil
+type ConfigurationUpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*ConfigurationChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
 }
 
-func (x *ExperimentLog) GetEnd() *timestamp.Timestamp {
-	if x != nil {
-		return x.End
+func (x *ConfigurationUpdateResponse) Reset() {
+	*x = ConfigurationUpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ExperimentLog) GetYamlConfiguration() string {
-	if x != nil {
-		return x.YamlConfiguration
-	}
-	return ""
+func (x *ConfigurationUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ExperimentLog) GetHasError() bool {
-	if x != nil {
-		return x.HasError
+func (*ConfigurationUpdateResponse) ProtoMessage() {}
+
+func (x *ConfigurationUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *ExperimentLog) GetError() string {
+// Deprecated: Use ConfigurationUpdateResponse.ProtoReflect.Descriptor instead.
+func (*ConfigurationUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConfigurationUpdateResponse) GetChanges() []*ConfigurationChange {
 	if x != nil {
-		return x.Error
+		return x.Changes
 	}
-	return ""
+	return nil
 }
 
-type TrackingLink struct {
+// clock synchronization of a slave node with its master. All values
+// are in microseconds.
+type ClockSynchronization struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Master string `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
-	Slave  string `protobuf:"bytes,2,opt,name=slave,proto3" json:"slave,omitempty"`
+	Producer string  `protobuf:"bytes,1,opt,name=producer,proto3" json:"producer,omitempty"`
+	OffsetUs float64 `protobuf:"fixed64,2,opt,name=offset_us,json=offsetUs,proto3" json:"offset_us,omitempty"`
+	// offset change since the first frame of the slave.
+	DriftUs  float64 `protobuf:"fixed64,3,opt,name=drift_us,json=driftUs,proto3" json:"drift_us,omitempty"`
+	JitterUs float64 `protobuf:"fixed64,4,opt,name=jitter_us,json=jitterUs,proto3" json:"jitter_us,omitempty"`
 }
 
-func (x *TrackingLink) Reset() {
-	*x = TrackingLink{}
+func (x *ClockSynchronization) Reset() {
+	*x = ClockSynchronization{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leto_service_proto_msgTypes[5]
+		mi := &file_leto_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TrackingLink) String() string {
+func (x *ClockSynchronization) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TrackingLink) ProtoMessage() {}
+func (*ClockSynchronization) ProtoMessage() {}
 
-func (x *TrackingLink) ProtoReflect() protoreflect.Message {
-	mi := &file_leto_service_proto_msgTypes[5]
+func (x *ClockSynchronization) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,157 +485,1593 @@ func (x *TrackingLink) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TrackingLink.ProtoReflect.Descriptor instead.
-func (*TrackingLink) Descriptor() ([]byte, []int) {
-	return file_leto_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ClockSynchronization.ProtoReflect.Descriptor instead.
+func (*ClockSynchronization) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *TrackingLink) GetMaster() string {
+func (x *ClockSynchronization) GetProducer() string {
 	if x != nil {
-		return x.Master
+		return x.Producer
 	}
 	return ""
 }
 
-func (x *TrackingLink) GetSlave() string {
+func (x *ClockSynchronization) GetOffsetUs() float64 {
 	if x != nil {
-		return x.Slave
+		return x.OffsetUs
 	}
-	return ""
+	return 0
 }
 
-var File_leto_service_proto protoreflect.FileDescriptor
-
-var file_leto_service_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x6c, 0x65, 0x74, 0x6f, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x3d, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d,
-	0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9a,
-	0x01, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
-	0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d,
-	0x65, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65,
-	0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x12, 0x2d, 0x0a, 0x12,
-	0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d, 0x6c, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe5, 0x01, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x6c, 0x61, 0x76, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69,
-	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6f, 0x72,
-	0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70,
-	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x65,
-	0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72,
-	0x65, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x66, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74,
-	0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x22, 0xa2, 0x02, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
-	0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12,
-	0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x69,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d,
-	0x65, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x3c, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63,
-	0x6b, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x74,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72,
-	0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x32, 0x9c, 0x03, 0x0a, 0x04, 0x4c, 0x65, 0x74, 0x6f, 0x12,
-	0x46, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67,
-	0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x54,
-	0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c,
-	0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x66,
-	0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74,
-	0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x16, 0x2e,
-	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74,
-	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
-	0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x3d, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1d, 0x2e,
-	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x16, 0x2e, 0x66,
-	0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x06, 0x55, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1d,
-	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x16, 0x2e,
-	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x6c, 0x65, 0x74, 0x6f, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *ClockSynchronization) GetDriftUs() float64 {
+	if x != nil {
+		return x.DriftUs
+	}
+	return 0
 }
 
-var (
-	file_leto_service_proto_rawDescOnce sync.Once
-	file_leto_service_proto_rawDescData = file_leto_service_proto_rawDesc
-)
-
-func file_leto_service_proto_rawDescGZIP() []byte {
-	file_leto_service_proto_rawDescOnce.Do(func() {
-		file_leto_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_leto_service_proto_rawDescData)
-	})
-	return file_leto_service_proto_rawDescData
+func (x *ClockSynchronization) GetJitterUs() float64 {
+	if x != nil {
+		return x.JitterUs
+	}
+	return 0
 }
 
-var file_leto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_leto_service_proto_goTypes = []interface{}{
-	(*Empty)(nil),               // 0: fort.leto.proto.Empty
-	(*StartRequest)(nil),        // 1: fort.leto.proto.StartRequest
-	(*ExperimentStatus)(nil),    // 2: fort.leto.proto.ExperimentStatus
-	(*Status)(nil),              // 3: fort.leto.proto.Status
-	(*ExperimentLog)(nil),       // 4: fort.leto.proto.ExperimentLog
-	(*TrackingLink)(nil),        // 5: fort.leto.proto.TrackingLink
-	(*timestamp.Timestamp)(nil), // 6: google.protobuf.Timestamp
-}
-var file_leto_service_proto_depIdxs = []int32{
-	6,  // 0: fort.leto.proto.ExperimentStatus.since:type_name -> google.protobuf.Timestamp
-	2,  // 1: fort.leto.proto.Status.experiment:type_name -> fort.leto.proto.ExperimentStatus
-	6,  // 2: fort.leto.proto.ExperimentLog.start:type_name -> google.protobuf.Timestamp
-	6,  // 3: fort.leto.proto.ExperimentLog.end:type_name -> google.protobuf.Timestamp
-	1,  // 4: fort.leto.proto.Leto.StartTracking:input_type -> fort.leto.proto.StartRequest
-	0,  // 5: fort.leto.proto.Leto.StopTracking:input_type -> fort.leto.proto.Empty
-	0,  // 6: fort.leto.proto.Leto.GetStatus:input_type -> fort.leto.proto.Empty
-	0,  // 7: fort.leto.proto.Leto.GetLastExperimentLog:input_type -> fort.leto.proto.Empty
-	5,  // 8: fort.leto.proto.Leto.Link:input_type -> fort.leto.proto.TrackingLink
-	5,  // 9: fort.leto.proto.Leto.Unlink:input_type -> fort.leto.proto.TrackingLink
-	0,  // 10: fort.leto.proto.Leto.StartTracking:output_type -> fort.leto.proto.Empty
-	0,  // 11: fort.leto.proto.Leto.StopTracking:output_type -> fort.leto.proto.Empty
-	3,  // 12: fort.leto.proto.Leto.GetStatus:output_type -> fort.leto.proto.Status
-	4,  // 13: fort.leto.proto.Leto.GetLastExperimentLog:output_type -> fort.leto.proto.ExperimentLog
-	0,  // 14: fort.leto.proto.Leto.Link:output_type -> fort.leto.proto.Empty
-	0,  // 15: fort.leto.proto.Leto.Unlink:output_type -> fort.leto.proto.Empty
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+type ExperimentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Since             *timestamp.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	ExperimentDir     string               `protobuf:"bytes,2,opt,name=experiment_dir,json=experimentDir,proto3" json:"experiment_dir,omitempty"`
+	YamlConfiguration string               `protobuf:"bytes,3,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	// frames dropped by each output of the master node.
+	DroppedFrames map[string]int64 `protobuf:"bytes,4,rep,name=dropped_frames,json=droppedFrames,proto3" json:"dropped_frames,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// clock synchronization of the slaves of a master node.
+	ClockOffsets   []*ClockSynchronization `protobuf:"bytes,5,rep,name=clock_offsets,json=clockOffsets,proto3" json:"clock_offsets,omitempty"`
+	LastMasterSync *timestamp.Timestamp    `protobuf:"bytes,6,opt,name=last_master_sync,json=lastMasterSync,proto3" json:"last_master_sync,omitempty"`
+	// layer which supplied each field of the configuration, by YAML path.
+	ConfigurationProvenance map[string]string `protobuf:"bytes,7,rep,name=configuration_provenance,json=configurationProvenance,proto3" json:"configuration_provenance,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// set while the experiment is paused.
+	PausedSince *timestamp.Timestamp `protobuf:"bytes,8,opt,name=paused_since,json=pausedSince,proto3" json:"paused_since,omitempty"`
+}
+
+func (x *ExperimentStatus) Reset() {
+	*x = ExperimentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentStatus) ProtoMessage() {}
+
+func (x *ExperimentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentStatus.ProtoReflect.Descriptor instead.
+func (*ExperimentStatus) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExperimentStatus) GetSince() *timestamp.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ExperimentStatus) GetExperimentDir() string {
+	if x != nil {
+		return x.ExperimentDir
+	}
+	return ""
+}
+
+func (x *ExperimentStatus) GetYamlConfiguration() string {
+	if x != nil {
+		return x.YamlConfiguration
+	}
+	return ""
+}
+
+func (x *ExperimentStatus) GetDroppedFrames() map[string]int64 {
+	if x != nil {
+		return x.DroppedFrames
+	}
+	return nil
+}
+
+func (x *ExperimentStatus) GetClockOffsets() []*ClockSynchronization {
+	if x != nil {
+		return x.ClockOffsets
+	}
+	return nil
+}
+
+func (x *ExperimentStatus) GetLastMasterSync() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastMasterSync
+	}
+	return nil
+}
+
+func (x *ExperimentStatus) GetConfigurationProvenance() map[string]string {
+	if x != nil {
+		return x.ConfigurationProvenance
+	}
+	return nil
+}
+
+func (x *ExperimentStatus) GetPausedSince() *timestamp.Timestamp {
+	if x != nil {
+		return x.PausedSince
+	}
+	return nil
+}
+
+type Status struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Master         string            `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
+	Slaves         []string          `protobuf:"bytes,2,rep,name=slaves,proto3" json:"slaves,omitempty"`
+	Experiment     *ExperimentStatus `protobuf:"bytes,3,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	TotalBytes     int64             `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	FreeBytes      int64             `protobuf:"varint,5,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"`
+	BytesPerSecond int64             `protobuf:"varint,6,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Status) GetMaster() string {
+	if x != nil {
+		return x.Master
+	}
+	return ""
+}
+
+func (x *Status) GetSlaves() []string {
+	if x != nil {
+		return x.Slaves
+	}
+	return nil
+}
+
+func (x *Status) GetExperiment() *ExperimentStatus {
+	if x != nil {
+		return x.Experiment
+	}
+	return nil
+}
+
+func (x *Status) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *Status) GetFreeBytes() int64 {
+	if x != nil {
+		return x.FreeBytes
+	}
+	return 0
+}
+
+func (x *Status) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+// a period where tracking was paused within an experiment.
+type ExperimentPause struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *ExperimentPause) Reset() {
+	*x = ExperimentPause{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentPause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentPause) ProtoMessage() {}
+
+func (x *ExperimentPause) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentPause.ProtoReflect.Descriptor instead.
+func (*ExperimentPause) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExperimentPause) GetStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ExperimentPause) GetEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type ExperimentLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Log               string               `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	Stderr            string               `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	ExperimentDir     string               `protobuf:"bytes,3,opt,name=experiment_dir,json=experimentDir,proto3" json:"experiment_dir,omitempty"`
+	Start             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End               *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	YamlConfiguration string               `protobuf:"bytes,6,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	HasError          bool                 `protobuf:"varint,7,opt,name=has_error,json=hasError,proto3" json:"has_error,omitempty"`
+	Error             string               `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Id                int32                `protobuf:"varint,9,opt,name=id,proto3" json:"id,omitempty"`
+	// layer which supplied each field of the configuration, by YAML path.
+	ConfigurationProvenance map[string]string  `protobuf:"bytes,10,rep,name=configuration_provenance,json=configurationProvenance,proto3" json:"configuration_provenance,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Pauses                  []*ExperimentPause `protobuf:"bytes,11,rep,name=pauses,proto3" json:"pauses,omitempty"`
+}
+
+func (x *ExperimentLog) Reset() {
+	*x = ExperimentLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentLog) ProtoMessage() {}
+
+func (x *ExperimentLog) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentLog.ProtoReflect.Descriptor instead.
+func (*ExperimentLog) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExperimentLog) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *ExperimentLog) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *ExperimentLog) GetExperimentDir() string {
+	if x != nil {
+		return x.ExperimentDir
+	}
+	return ""
+}
+
+func (x *ExperimentLog) GetStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ExperimentLog) GetEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ExperimentLog) GetYamlConfiguration() string {
+	if x != nil {
+		return x.YamlConfiguration
+	}
+	return ""
+}
+
+func (x *ExperimentLog) GetHasError() bool {
+	if x != nil {
+		return x.HasError
+	}
+	return false
+}
+
+func (x *ExperimentLog) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExperimentLog) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ExperimentLog) GetConfigurationProvenance() map[string]string {
+	if x != nil {
+		return x.ConfigurationProvenance
+	}
+	return nil
+}
+
+func (x *ExperimentLog) GetPauses() []*ExperimentPause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+type ExperimentLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ExperimentLogRequest) Reset() {
+	*x = ExperimentLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentLogRequest) ProtoMessage() {}
+
+func (x *ExperimentLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentLogRequest.ProtoReflect.Descriptor instead.
+func (*ExperimentLogRequest) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExperimentLogRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ExperimentLogList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Experiments []*ExperimentLog `protobuf:"bytes,1,rep,name=experiments,proto3" json:"experiments,omitempty"`
+}
+
+func (x *ExperimentLogList) Reset() {
+	*x = ExperimentLogList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentLogList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentLogList) ProtoMessage() {}
+
+func (x *ExperimentLogList) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentLogList.ProtoReflect.Descriptor instead.
+func (*ExperimentLogList) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExperimentLogList) GetExperiments() []*ExperimentLog {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+type ScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	YamlConfiguration string               `protobuf:"bytes,1,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	Start             *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End               *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *ScheduleRequest) Reset() {
+	*x = ScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRequest) ProtoMessage() {}
+
+func (x *ScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScheduleRequest) GetYamlConfiguration() string {
+	if x != nil {
+		return x.YamlConfiguration
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type ScheduledExperiment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	YamlConfiguration string               `protobuf:"bytes,2,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+	Start             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End               *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Started           bool                 `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+}
+
+func (x *ScheduledExperiment) Reset() {
+	*x = ScheduledExperiment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledExperiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledExperiment) ProtoMessage() {}
+
+func (x *ScheduledExperiment) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledExperiment.ProtoReflect.Descriptor instead.
+func (*ScheduledExperiment) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ScheduledExperiment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduledExperiment) GetYamlConfiguration() string {
+	if x != nil {
+		return x.YamlConfiguration
+	}
+	return ""
+}
+
+func (x *ScheduledExperiment) GetStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ScheduledExperiment) GetEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ScheduledExperiment) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+type ScheduledExperimentList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedules []*ScheduledExperiment `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *ScheduledExperimentList) Reset() {
+	*x = ScheduledExperimentList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledExperimentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledExperimentList) ProtoMessage() {}
+
+func (x *ScheduledExperimentList) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledExperimentList.ProtoReflect.Descriptor instead.
+func (*ScheduledExperimentList) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ScheduledExperimentList) GetSchedules() []*ScheduledExperiment {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type ScheduleCancelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ScheduleCancelRequest) Reset() {
+	*x = ScheduleCancelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleCancelRequest) ProtoMessage() {}
+
+func (x *ScheduleCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleCancelRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleCancelRequest) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ScheduleCancelRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// a named tracking configuration stored on a node.
+type Preset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	YamlConfiguration string `protobuf:"bytes,2,opt,name=yaml_configuration,json=yamlConfiguration,proto3" json:"yaml_configuration,omitempty"`
+}
+
+func (x *Preset) Reset() {
+	*x = Preset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Preset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preset) ProtoMessage() {}
+
+func (x *Preset) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preset.ProtoReflect.Descriptor instead.
+func (*Preset) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Preset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Preset) GetYamlConfiguration() string {
+	if x != nil {
+		return x.YamlConfiguration
+	}
+	return ""
+}
+
+type PresetList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Presets []*Preset `protobuf:"bytes,1,rep,name=presets,proto3" json:"presets,omitempty"`
+}
+
+func (x *PresetList) Reset() {
+	*x = PresetList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresetList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresetList) ProtoMessage() {}
+
+func (x *PresetList) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresetList.ProtoReflect.Descriptor instead.
+func (*PresetList) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PresetList) GetPresets() []*Preset {
+	if x != nil {
+		return x.Presets
+	}
+	return nil
+}
+
+type PresetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *PresetRequest) Reset() {
+	*x = PresetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresetRequest) ProtoMessage() {}
+
+func (x *PresetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresetRequest.ProtoReflect.Descriptor instead.
+func (*PresetRequest) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PresetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type TrackingLink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Master string `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
+	Slave  string `protobuf:"bytes,2,opt,name=slave,proto3" json:"slave,omitempty"`
+}
+
+func (x *TrackingLink) Reset() {
+	*x = TrackingLink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackingLink) ProtoMessage() {}
+
+func (x *TrackingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackingLink.ProtoReflect.Descriptor instead.
+func (*TrackingLink) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TrackingLink) GetMaster() string {
+	if x != nil {
+		return x.Master
+	}
+	return ""
+}
+
+func (x *TrackingLink) GetSlave() string {
+	if x != nil {
+		return x.Slave
+	}
+	return ""
+}
+
+// A BroadcastSubscription could be sent by a client of the hermes
+// broadcast stream, after it received the Header, to tailor the
+// FrameReadouts it receives.
+type BroadcastSubscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only these tags are sent, all tags if empty.
+	TagIds []uint32 `protobuf:"varint,1,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	// only one frame every decimation is sent, all frames if 0 or 1.
+	Decimation uint32 `protobuf:"varint,2,opt,name=decimation,proto3" json:"decimation,omitempty"`
+	// tag positions are stripped, only their ID are sent.
+	CountsOnly bool `protobuf:"varint,3,opt,name=counts_only,json=countsOnly,proto3" json:"counts_only,omitempty"`
+}
+
+func (x *BroadcastSubscription) Reset() {
+	*x = BroadcastSubscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastSubscription) ProtoMessage() {}
+
+func (x *BroadcastSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastSubscription.ProtoReflect.Descriptor instead.
+func (*BroadcastSubscription) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BroadcastSubscription) GetTagIds() []uint32 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *BroadcastSubscription) GetDecimation() uint32 {
+	if x != nil {
+		return x.Decimation
+	}
+	return 0
+}
+
+func (x *BroadcastSubscription) GetCountsOnly() bool {
+	if x != nil {
+		return x.CountsOnly
+	}
+	return false
+}
+
+type BroadcastClient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Since   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// number of frames dropped because the client was too slow.
+	Dropped int64 `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *BroadcastClient) Reset() {
+	*x = BroadcastClient{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastClient) ProtoMessage() {}
+
+func (x *BroadcastClient) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastClient.ProtoReflect.Descriptor instead.
+func (*BroadcastClient) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BroadcastClient) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *BroadcastClient) GetSince() *timestamp.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *BroadcastClient) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type BroadcastClientList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Clients []*BroadcastClient `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+}
+
+func (x *BroadcastClientList) Reset() {
+	*x = BroadcastClientList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leto_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastClientList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastClientList) ProtoMessage() {}
+
+func (x *BroadcastClientList) ProtoReflect() protoreflect.Message {
+	mi := &file_leto_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastClientList.ProtoReflect.Descriptor instead.
+func (*BroadcastClientList) Descriptor() ([]byte, []int) {
+	return file_leto_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BroadcastClientList) GetClients() []*BroadcastClient {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+var File_leto_service_proto protoreflect.FileDescriptor
+
+var file_leto_service_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x6c, 0x65, 0x74, 0x6f, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x55, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d,
+	0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x22, 0x47, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65,
+	0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x22,
+	0x42, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61,
+	0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x72, 0x74,
+	0x65, 0x6d, 0x69, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x61, 0x72, 0x74, 0x65, 0x6d, 0x69, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65,
+	0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x22, 0x44, 0x0a, 0x13, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61,
+	0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x5b, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5d, 0x0a, 0x1b,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66,
+	0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x14,
+	0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72,
+	0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x55, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x64, 0x72, 0x69, 0x66, 0x74, 0x5f, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x55, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x5f, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6a, 0x69, 0x74,
+	0x74, 0x65, 0x72, 0x55, 0x73, 0x22, 0xd1, 0x05, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69,
+	0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x44, 0x69, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x79, 0x61, 0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x5b, 0x0a, 0x0e, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x44, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12,
+	0x4a, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65,
+	0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x44, 0x0a, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x79, 0x6e,
+	0x63, 0x12, 0x79, 0x0a, 0x18, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0c,
+	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b,
+	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x1a, 0x40, 0x0a, 0x12, 0x44,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4a, 0x0a,
+	0x1c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe5, 0x01, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6c, 0x61, 0x76, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6c,
+	0x61, 0x76, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e,
+	0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x65, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x72,
+	0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x22, 0x71, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x22, 0xb0, 0x04, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d,
+	0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65,
+	0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64,
+	0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69,
+	0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x76, 0x0a, 0x18, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78,
+	0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x38, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61,
+	0x75, 0x73, 0x65, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x73, 0x1a, 0x4a, 0x0a, 0x1c, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x26, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x55, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65,
+	0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x0f, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61,
+	0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61, 0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0xce, 0x01, 0x0a, 0x13, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79,
+	0x61, 0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x65, 0x6e, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x22, 0x5d, 0x0a, 0x17, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e,
+	0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e,
+	0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x15, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x4b, 0x0a, 0x06, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x2d, 0x0a, 0x12, 0x79, 0x61, 0x6d, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x79, 0x61,
+	0x6d, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x3f, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x31, 0x0a,
+	0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x52, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73,
+	0x22, 0x23, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3c, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e,
+	0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x6c,
+	0x61, 0x76, 0x65, 0x22, 0x71, 0x0a, 0x15, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07,
+	0x74, 0x61, 0x67, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x74,
+	0x61, 0x67, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x77, 0x0a, 0x0f, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22,
+	0x51, 0x0a, 0x13, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c,
+	0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x73, 0x32, 0x8d, 0x0d, 0x0a, 0x04, 0x4c, 0x65, 0x74, 0x6f, 0x12, 0x48, 0x0a, 0x0f, 0x50,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1d,
+	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4e, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65,
+	0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x15, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69,
+	0x6e, 0x67, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x3f, 0x0a, 0x0d, 0x50, 0x61, 0x75, 0x73, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x72, 0x61,
+	0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e,
+	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x69, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x66,
+	0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x1a, 0x2c, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e,
+	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x40,
+	0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e,
+	0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x30, 0x01,
+	0x12, 0x4e, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e,
+	0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67,
+	0x12, 0x4d, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x22, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78,
+	0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x59, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x4c, 0x6f, 0x67, 0x12, 0x25, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x72,
+	0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x5a, 0x0a, 0x10, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x20,
+	0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x24, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x45, 0x78, 0x70, 0x65,
+	0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x51, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c,
+	0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x28, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x0e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x26, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x0a, 0x53,
+	0x61, 0x76, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x65, 0x73,
+	0x65, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74,
+	0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x1b, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x44,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x1e, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66, 0x6f,
+	0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x12, 0x46, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x04,
+	0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x4c,
+	0x69, 0x6e, 0x6b, 0x1a, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x06, 0x55,
+	0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67,
+	0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x54, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x24, 0x2e, 0x66,
+	0x6f, 0x72, 0x74, 0x2e, 0x6c, 0x65, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x6c, 0x65, 0x74, 0x6f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_leto_service_proto_rawDescOnce sync.Once
+	file_leto_service_proto_rawDescData = file_leto_service_proto_rawDesc
+)
+
+func file_leto_service_proto_rawDescGZIP() []byte {
+	file_leto_service_proto_rawDescOnce.Do(func() {
+		file_leto_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_leto_service_proto_rawDescData)
+	})
+	return file_leto_service_proto_rawDescData
+}
+
+var file_leto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_leto_service_proto_goTypes = []interface{}{
+	(*Empty)(nil),                       // 0: fort.leto.proto.Empty
+	(*StartRequest)(nil),                // 1: fort.leto.proto.StartRequest
+	(*NodeResult)(nil),                  // 2: fort.leto.proto.NodeResult
+	(*StartResponse)(nil),               // 3: fort.leto.proto.StartResponse
+	(*ValidationResponse)(nil),          // 4: fort.leto.proto.ValidationResponse
+	(*ConfigurationUpdate)(nil),         // 5: fort.leto.proto.ConfigurationUpdate
+	(*ConfigurationChange)(nil),         // 6: fort.leto.proto.ConfigurationChange
+	(*ConfigurationUpdateResponse)(nil), // 7: fort.leto.proto.ConfigurationUpdateResponse
+	(*ClockSynchronization)(nil),        // 8: fort.leto.proto.ClockSynchronization
+	(*ExperimentStatus)(nil),            // 9: fort.leto.proto.ExperimentStatus
+	(*Status)(nil),                      // 10: fort.leto.proto.Status
+	(*ExperimentPause)(nil),             // 11: fort.leto.proto.ExperimentPause
+	(*ExperimentLog)(nil),               // 12: fort.leto.proto.ExperimentLog
+	(*ExperimentLogRequest)(nil),        // 13: fort.leto.proto.ExperimentLogRequest
+	(*ExperimentLogList)(nil),           // 14: fort.leto.proto.ExperimentLogList
+	(*ScheduleRequest)(nil),             // 15: fort.leto.proto.ScheduleRequest
+	(*ScheduledExperiment)(nil),         // 16: fort.leto.proto.ScheduledExperiment
+	(*ScheduledExperimentList)(nil),     // 17: fort.leto.proto.ScheduledExperimentList
+	(*ScheduleCancelRequest)(nil),       // 18: fort.leto.proto.ScheduleCancelRequest
+	(*Preset)(nil),                      // 19: fort.leto.proto.Preset
+	(*PresetList)(nil),                  // 20: fort.leto.proto.PresetList
+	(*PresetRequest)(nil),               // 21: fort.leto.proto.PresetRequest
+	(*TrackingLink)(nil),                // 22: fort.leto.proto.TrackingLink
+	(*BroadcastSubscription)(nil),       // 23: fort.leto.proto.BroadcastSubscription
+	(*BroadcastClient)(nil),             // 24: fort.leto.proto.BroadcastClient
+	(*BroadcastClientList)(nil),         // 25: fort.leto.proto.BroadcastClientList
+	nil,                                 // 26: fort.leto.proto.ExperimentStatus.DroppedFramesEntry
+	nil,                                 // 27: fort.leto.proto.ExperimentStatus.ConfigurationProvenanceEntry
+	nil,                                 // 28: fort.leto.proto.ExperimentLog.ConfigurationProvenanceEntry
+	(*timestamp.Timestamp)(nil),         // 29: google.protobuf.Timestamp
+}
+var file_leto_service_proto_depIdxs = []int32{
+	2,  // 0: fort.leto.proto.StartResponse.nodes:type_name -> fort.leto.proto.NodeResult
+	6,  // 1: fort.leto.proto.ConfigurationUpdateResponse.changes:type_name -> fort.leto.proto.ConfigurationChange
+	29, // 2: fort.leto.proto.ExperimentStatus.since:type_name -> google.protobuf.Timestamp
+	26, // 3: fort.leto.proto.ExperimentStatus.dropped_frames:type_name -> fort.leto.proto.ExperimentStatus.DroppedFramesEntry
+	8,  // 4: fort.leto.proto.ExperimentStatus.clock_offsets:type_name -> fort.leto.proto.ClockSynchronization
+	29, // 5: fort.leto.proto.ExperimentStatus.last_master_sync:type_name -> google.protobuf.Timestamp
+	27, // 6: fort.leto.proto.ExperimentStatus.configuration_provenance:type_name -> fort.leto.proto.ExperimentStatus.ConfigurationProvenanceEntry
+	29, // 7: fort.leto.proto.ExperimentStatus.paused_since:type_name -> google.protobuf.Timestamp
+	9,  // 8: fort.leto.proto.Status.experiment:type_name -> fort.leto.proto.ExperimentStatus
+	29, // 9: fort.leto.proto.ExperimentPause.start:type_name -> google.protobuf.Timestamp
+	29, // 10: fort.leto.proto.ExperimentPause.end:type_name -> google.protobuf.Timestamp
+	29, // 11: fort.leto.proto.ExperimentLog.start:type_name -> google.protobuf.Timestamp
+	29, // 12: fort.leto.proto.ExperimentLog.end:type_name -> google.protobuf.Timestamp
+	28, // 13: fort.leto.proto.ExperimentLog.configuration_provenance:type_name -> fort.leto.proto.ExperimentLog.ConfigurationProvenanceEntry
+	11, // 14: fort.leto.proto.ExperimentLog.pauses:type_name -> fort.leto.proto.ExperimentPause
+	12, // 15: fort.leto.proto.ExperimentLogList.experiments:type_name -> fort.leto.proto.ExperimentLog
+	29, // 16: fort.leto.proto.ScheduleRequest.start:type_name -> google.protobuf.Timestamp
+	29, // 17: fort.leto.proto.ScheduleRequest.end:type_name -> google.protobuf.Timestamp
+	29, // 18: fort.leto.proto.ScheduledExperiment.start:type_name -> google.protobuf.Timestamp
+	29, // 19: fort.leto.proto.ScheduledExperiment.end:type_name -> google.protobuf.Timestamp
+	16, // 20: fort.leto.proto.ScheduledExperimentList.schedules:type_name -> fort.leto.proto.ScheduledExperiment
+	19, // 21: fort.leto.proto.PresetList.presets:type_name -> fort.leto.proto.Preset
+	29, // 22: fort.leto.proto.BroadcastClient.since:type_name -> google.protobuf.Timestamp
+	24, // 23: fort.leto.proto.BroadcastClientList.clients:type_name -> fort.leto.proto.BroadcastClient
+	1,  // 24: fort.leto.proto.Leto.PrepareTracking:input_type -> fort.leto.proto.StartRequest
+	1,  // 25: fort.leto.proto.Leto.StartTracking:input_type -> fort.leto.proto.StartRequest
+	1,  // 26: fort.leto.proto.Leto.ValidateConfiguration:input_type -> fort.leto.proto.StartRequest
+	0,  // 27: fort.leto.proto.Leto.StopTracking:input_type -> fort.leto.proto.Empty
+	0,  // 28: fort.leto.proto.Leto.PauseTracking:input_type -> fort.leto.proto.Empty
+	0,  // 29: fort.leto.proto.Leto.ResumeTracking:input_type -> fort.leto.proto.Empty
+	5,  // 30: fort.leto.proto.Leto.UpdateConfiguration:input_type -> fort.leto.proto.ConfigurationUpdate
+	0,  // 31: fort.leto.proto.Leto.GetStatus:input_type -> fort.leto.proto.Empty
+	0,  // 32: fort.leto.proto.Leto.WatchStatus:input_type -> fort.leto.proto.Empty
+	0,  // 33: fort.leto.proto.Leto.GetLastExperimentLog:input_type -> fort.leto.proto.Empty
+	0,  // 34: fort.leto.proto.Leto.ListExperiments:input_type -> fort.leto.proto.Empty
+	13, // 35: fort.leto.proto.Leto.GetExperimentLog:input_type -> fort.leto.proto.ExperimentLogRequest
+	15, // 36: fort.leto.proto.Leto.ScheduleTracking:input_type -> fort.leto.proto.ScheduleRequest
+	0,  // 37: fort.leto.proto.Leto.ListSchedules:input_type -> fort.leto.proto.Empty
+	18, // 38: fort.leto.proto.Leto.CancelSchedule:input_type -> fort.leto.proto.ScheduleCancelRequest
+	19, // 39: fort.leto.proto.Leto.SavePreset:input_type -> fort.leto.proto.Preset
+	0,  // 40: fort.leto.proto.Leto.ListPresets:input_type -> fort.leto.proto.Empty
+	21, // 41: fort.leto.proto.Leto.GetPreset:input_type -> fort.leto.proto.PresetRequest
+	21, // 42: fort.leto.proto.Leto.DeletePreset:input_type -> fort.leto.proto.PresetRequest
+	22, // 43: fort.leto.proto.Leto.Link:input_type -> fort.leto.proto.TrackingLink
+	22, // 44: fort.leto.proto.Leto.Unlink:input_type -> fort.leto.proto.TrackingLink
+	0,  // 45: fort.leto.proto.Leto.ListBroadcastClients:input_type -> fort.leto.proto.Empty
+	0,  // 46: fort.leto.proto.Leto.PrepareTracking:output_type -> fort.leto.proto.Empty
+	3,  // 47: fort.leto.proto.Leto.StartTracking:output_type -> fort.leto.proto.StartResponse
+	4,  // 48: fort.leto.proto.Leto.ValidateConfiguration:output_type -> fort.leto.proto.ValidationResponse
+	0,  // 49: fort.leto.proto.Leto.StopTracking:output_type -> fort.leto.proto.Empty
+	0,  // 50: fort.leto.proto.Leto.PauseTracking:output_type -> fort.leto.proto.Empty
+	0,  // 51: fort.leto.proto.Leto.ResumeTracking:output_type -> fort.leto.proto.Empty
+	7,  // 52: fort.leto.proto.Leto.UpdateConfiguration:output_type -> fort.leto.proto.ConfigurationUpdateResponse
+	10, // 53: fort.leto.proto.Leto.GetStatus:output_type -> fort.leto.proto.Status
+	10, // 54: fort.leto.proto.Leto.WatchStatus:output_type -> fort.leto.proto.Status
+	12, // 55: fort.leto.proto.Leto.GetLastExperimentLog:output_type -> fort.leto.proto.ExperimentLog
+	14, // 56: fort.leto.proto.Leto.ListExperiments:output_type -> fort.leto.proto.ExperimentLogList
+	12, // 57: fort.leto.proto.Leto.GetExperimentLog:output_type -> fort.leto.proto.ExperimentLog
+	16, // 58: fort.leto.proto.Leto.ScheduleTracking:output_type -> fort.leto.proto.ScheduledExperiment
+	17, // 59: fort.leto.proto.Leto.ListSchedules:output_type -> fort.leto.proto.ScheduledExperimentList
+	0,  // 60: fort.leto.proto.Leto.CancelSchedule:output_type -> fort.leto.proto.Empty
+	0,  // 61: fort.leto.proto.Leto.SavePreset:output_type -> fort.leto.proto.Empty
+	20, // 62: fort.leto.proto.Leto.ListPresets:output_type -> fort.leto.proto.PresetList
+	19, // 63: fort.leto.proto.Leto.GetPreset:output_type -> fort.leto.proto.Preset
+	0,  // 64: fort.leto.proto.Leto.DeletePreset:output_type -> fort.leto.proto.Empty
+	0,  // 65: fort.leto.proto.Leto.Link:output_type -> fort.leto.proto.Empty
+	0,  // 66: fort.leto.proto.Leto.Unlink:output_type -> fort.leto.proto.Empty
+	25, // 67: fort.leto.proto.Leto.ListBroadcastClients:output_type -> fort.leto.proto.BroadcastClientList
+	46, // [46:68] is the sub-list for method output_type
+	24, // [24:46] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_leto_service_proto_init() }
@@ -579,7 +2105,7 @@ func file_leto_service_proto_init() {
 			}
 		}
 		file_leto_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExperimentStatus); i {
+			switch v := v.(*NodeResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -591,7 +2117,7 @@ func file_leto_service_proto_init() {
 			}
 		}
 		file_leto_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Status); i {
+			switch v := v.(*StartResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -603,7 +2129,7 @@ func file_leto_service_proto_init() {
 			}
 		}
 		file_leto_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExperimentLog); i {
+			switch v := v.(*ValidationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -615,6 +2141,210 @@ func file_leto_service_proto_init() {
 			}
 		}
 		file_leto_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigurationUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigurationChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigurationUpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClockSynchronization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Status); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentPause); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentLogList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledExperiment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledExperimentList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleCancelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Preset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresetList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TrackingLink); i {
 			case 0:
 				return &v.state
@@ -626,6 +2356,42 @@ func file_leto_service_proto_init() {
 				return nil
 			}
 		}
+		file_leto_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastSubscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leto_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastClientList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -633,7 +2399,7 @@ func file_leto_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_leto_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
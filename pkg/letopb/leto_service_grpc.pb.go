@@ -22,12 +22,34 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LetoClient interface {
-	StartTracking(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*Empty, error)
+	// checks that StartTracking would succeed, without starting
+	// anything.
+	PrepareTracking(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*Empty, error)
+	StartTracking(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	ValidateConfiguration(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*ValidationResponse, error)
 	StopTracking(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// stops artemis and all outputs of the running experiment, which
+	// continues in the same directory once resumed.
+	PauseTracking(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	ResumeTracking(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// applies the changes which could be made without restarting the
+	// running experiment. If any change could not, none is applied.
+	UpdateConfiguration(ctx context.Context, in *ConfigurationUpdate, opts ...grpc.CallOption) (*ConfigurationUpdateResponse, error)
 	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Status, error)
+	WatchStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Leto_WatchStatusClient, error)
 	GetLastExperimentLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExperimentLog, error)
+	ListExperiments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExperimentLogList, error)
+	GetExperimentLog(ctx context.Context, in *ExperimentLogRequest, opts ...grpc.CallOption) (*ExperimentLog, error)
+	ScheduleTracking(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduledExperiment, error)
+	ListSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScheduledExperimentList, error)
+	CancelSchedule(ctx context.Context, in *ScheduleCancelRequest, opts ...grpc.CallOption) (*Empty, error)
+	SavePreset(ctx context.Context, in *Preset, opts ...grpc.CallOption) (*Empty, error)
+	ListPresets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PresetList, error)
+	GetPreset(ctx context.Context, in *PresetRequest, opts ...grpc.CallOption) (*Preset, error)
+	DeletePreset(ctx context.Context, in *PresetRequest, opts ...grpc.CallOption) (*Empty, error)
 	Link(ctx context.Context, in *TrackingLink, opts ...grpc.CallOption) (*Empty, error)
 	Unlink(ctx context.Context, in *TrackingLink, opts ...grpc.CallOption) (*Empty, error)
+	ListBroadcastClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BroadcastClientList, error)
 }
 
 type letoClient struct {
@@ -38,8 +60,17 @@ func NewLetoClient(cc grpc.ClientConnInterface) LetoClient {
 	return &letoClient{cc}
 }
 
-func (c *letoClient) StartTracking(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (c *letoClient) PrepareTracking(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/PrepareTracking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) StartTracking(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	out := new(StartResponse)
 	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/StartTracking", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -47,6 +78,15 @@ func (c *letoClient) StartTracking(ctx context.Context, in *StartRequest, opts .
 	return out, nil
 }
 
+func (c *letoClient) ValidateConfiguration(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*ValidationResponse, error) {
+	out := new(ValidationResponse)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ValidateConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *letoClient) StopTracking(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/StopTracking", in, out, opts...)
@@ -56,6 +96,33 @@ func (c *letoClient) StopTracking(ctx context.Context, in *Empty, opts ...grpc.C
 	return out, nil
 }
 
+func (c *letoClient) PauseTracking(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/PauseTracking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) ResumeTracking(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ResumeTracking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) UpdateConfiguration(ctx context.Context, in *ConfigurationUpdate, opts ...grpc.CallOption) (*ConfigurationUpdateResponse, error) {
+	out := new(ConfigurationUpdateResponse)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/UpdateConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *letoClient) GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Status, error) {
 	out := new(Status)
 	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/GetStatus", in, out, opts...)
@@ -65,6 +132,38 @@ func (c *letoClient) GetStatus(ctx context.Context, in *Empty, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *letoClient) WatchStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Leto_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Leto_ServiceDesc.Streams[0], "/fort.leto.proto.Leto/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &letoWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Leto_WatchStatusClient interface {
+	Recv() (*Status, error)
+	grpc.ClientStream
+}
+
+type letoWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *letoWatchStatusClient) Recv() (*Status, error) {
+	m := new(Status)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *letoClient) GetLastExperimentLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExperimentLog, error) {
 	out := new(ExperimentLog)
 	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/GetLastExperimentLog", in, out, opts...)
@@ -74,6 +173,87 @@ func (c *letoClient) GetLastExperimentLog(ctx context.Context, in *Empty, opts .
 	return out, nil
 }
 
+func (c *letoClient) ListExperiments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExperimentLogList, error) {
+	out := new(ExperimentLogList)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ListExperiments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) GetExperimentLog(ctx context.Context, in *ExperimentLogRequest, opts ...grpc.CallOption) (*ExperimentLog, error) {
+	out := new(ExperimentLog)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/GetExperimentLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) ScheduleTracking(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduledExperiment, error) {
+	out := new(ScheduledExperiment)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ScheduleTracking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) ListSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScheduledExperimentList, error) {
+	out := new(ScheduledExperimentList)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) CancelSchedule(ctx context.Context, in *ScheduleCancelRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/CancelSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) SavePreset(ctx context.Context, in *Preset, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/SavePreset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) ListPresets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PresetList, error) {
+	out := new(PresetList)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ListPresets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) GetPreset(ctx context.Context, in *PresetRequest, opts ...grpc.CallOption) (*Preset, error) {
+	out := new(Preset)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/GetPreset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *letoClient) DeletePreset(ctx context.Context, in *PresetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/DeletePreset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *letoClient) Link(ctx context.Context, in *TrackingLink, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/Link", in, out, opts...)
@@ -92,16 +272,47 @@ func (c *letoClient) Unlink(ctx context.Context, in *TrackingLink, opts ...grpc.
 	return out, nil
 }
 
+func (c *letoClient) ListBroadcastClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BroadcastClientList, error) {
+	out := new(BroadcastClientList)
+	err := c.cc.Invoke(ctx, "/fort.leto.proto.Leto/ListBroadcastClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LetoServer is the server API for Leto service.
 // All implementations must embed UnimplementedLetoServer
 // for forward compatibility
 type LetoServer interface {
-	StartTracking(context.Context, *StartRequest) (*Empty, error)
+	// checks that StartTracking would succeed, without starting
+	// anything.
+	PrepareTracking(context.Context, *StartRequest) (*Empty, error)
+	StartTracking(context.Context, *StartRequest) (*StartResponse, error)
+	ValidateConfiguration(context.Context, *StartRequest) (*ValidationResponse, error)
 	StopTracking(context.Context, *Empty) (*Empty, error)
+	// stops artemis and all outputs of the running experiment, which
+	// continues in the same directory once resumed.
+	PauseTracking(context.Context, *Empty) (*Empty, error)
+	ResumeTracking(context.Context, *Empty) (*Empty, error)
+	// applies the changes which could be made without restarting the
+	// running experiment. If any change could not, none is applied.
+	UpdateConfiguration(context.Context, *ConfigurationUpdate) (*ConfigurationUpdateResponse, error)
 	GetStatus(context.Context, *Empty) (*Status, error)
+	WatchStatus(*Empty, Leto_WatchStatusServer) error
 	GetLastExperimentLog(context.Context, *Empty) (*ExperimentLog, error)
+	ListExperiments(context.Context, *Empty) (*ExperimentLogList, error)
+	GetExperimentLog(context.Context, *ExperimentLogRequest) (*ExperimentLog, error)
+	ScheduleTracking(context.Context, *ScheduleRequest) (*ScheduledExperiment, error)
+	ListSchedules(context.Context, *Empty) (*ScheduledExperimentList, error)
+	CancelSchedule(context.Context, *ScheduleCancelRequest) (*Empty, error)
+	SavePreset(context.Context, *Preset) (*Empty, error)
+	ListPresets(context.Context, *Empty) (*PresetList, error)
+	GetPreset(context.Context, *PresetRequest) (*Preset, error)
+	DeletePreset(context.Context, *PresetRequest) (*Empty, error)
 	Link(context.Context, *TrackingLink) (*Empty, error)
 	Unlink(context.Context, *TrackingLink) (*Empty, error)
+	ListBroadcastClients(context.Context, *Empty) (*BroadcastClientList, error)
 	mustEmbedUnimplementedLetoServer()
 }
 
@@ -109,24 +320,72 @@ type LetoServer interface {
 type UnimplementedLetoServer struct {
 }
 
-func (UnimplementedLetoServer) StartTracking(context.Context, *StartRequest) (*Empty, error) {
+func (UnimplementedLetoServer) PrepareTracking(context.Context, *StartRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareTracking not implemented")
+}
+func (UnimplementedLetoServer) StartTracking(context.Context, *StartRequest) (*StartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartTracking not implemented")
 }
+func (UnimplementedLetoServer) ValidateConfiguration(context.Context, *StartRequest) (*ValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateConfiguration not implemented")
+}
 func (UnimplementedLetoServer) StopTracking(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopTracking not implemented")
 }
+func (UnimplementedLetoServer) PauseTracking(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseTracking not implemented")
+}
+func (UnimplementedLetoServer) ResumeTracking(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeTracking not implemented")
+}
+func (UnimplementedLetoServer) UpdateConfiguration(context.Context, *ConfigurationUpdate) (*ConfigurationUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfiguration not implemented")
+}
 func (UnimplementedLetoServer) GetStatus(context.Context, *Empty) (*Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedLetoServer) WatchStatus(*Empty, Leto_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
 func (UnimplementedLetoServer) GetLastExperimentLog(context.Context, *Empty) (*ExperimentLog, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLastExperimentLog not implemented")
 }
+func (UnimplementedLetoServer) ListExperiments(context.Context, *Empty) (*ExperimentLogList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExperiments not implemented")
+}
+func (UnimplementedLetoServer) GetExperimentLog(context.Context, *ExperimentLogRequest) (*ExperimentLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExperimentLog not implemented")
+}
+func (UnimplementedLetoServer) ScheduleTracking(context.Context, *ScheduleRequest) (*ScheduledExperiment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleTracking not implemented")
+}
+func (UnimplementedLetoServer) ListSchedules(context.Context, *Empty) (*ScheduledExperimentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedLetoServer) CancelSchedule(context.Context, *ScheduleCancelRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+func (UnimplementedLetoServer) SavePreset(context.Context, *Preset) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavePreset not implemented")
+}
+func (UnimplementedLetoServer) ListPresets(context.Context, *Empty) (*PresetList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPresets not implemented")
+}
+func (UnimplementedLetoServer) GetPreset(context.Context, *PresetRequest) (*Preset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreset not implemented")
+}
+func (UnimplementedLetoServer) DeletePreset(context.Context, *PresetRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePreset not implemented")
+}
 func (UnimplementedLetoServer) Link(context.Context, *TrackingLink) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Link not implemented")
 }
 func (UnimplementedLetoServer) Unlink(context.Context, *TrackingLink) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Unlink not implemented")
 }
+func (UnimplementedLetoServer) ListBroadcastClients(context.Context, *Empty) (*BroadcastClientList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBroadcastClients not implemented")
+}
 func (UnimplementedLetoServer) mustEmbedUnimplementedLetoServer() {}
 
 // UnsafeLetoServer may be embedded to opt out of forward compatibility for this service.
@@ -140,6 +399,24 @@ func RegisterLetoServer(s grpc.ServiceRegistrar, srv LetoServer) {
 	s.RegisterService(&Leto_ServiceDesc, srv)
 }
 
+func _Leto_PrepareTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).PrepareTracking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/PrepareTracking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).PrepareTracking(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Leto_StartTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartRequest)
 	if err := dec(in); err != nil {
@@ -158,6 +435,24 @@ func _Leto_StartTracking_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Leto_ValidateConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ValidateConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ValidateConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ValidateConfiguration(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Leto_StopTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -176,6 +471,60 @@ func _Leto_StopTracking_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Leto_PauseTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).PauseTracking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/PauseTracking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).PauseTracking(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_ResumeTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ResumeTracking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ResumeTracking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ResumeTracking(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_UpdateConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigurationUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).UpdateConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/UpdateConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).UpdateConfiguration(ctx, req.(*ConfigurationUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Leto_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -194,6 +543,27 @@ func _Leto_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Leto_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LetoServer).WatchStatus(m, &letoWatchStatusServer{stream})
+}
+
+type Leto_WatchStatusServer interface {
+	Send(*Status) error
+	grpc.ServerStream
+}
+
+type letoWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *letoWatchStatusServer) Send(m *Status) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Leto_GetLastExperimentLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -212,6 +582,168 @@ func _Leto_GetLastExperimentLog_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Leto_ListExperiments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ListExperiments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ListExperiments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ListExperiments(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_GetExperimentLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExperimentLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).GetExperimentLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/GetExperimentLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).GetExperimentLog(ctx, req.(*ExperimentLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_ScheduleTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ScheduleTracking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ScheduleTracking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ScheduleTracking(ctx, req.(*ScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ListSchedules(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/CancelSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).CancelSchedule(ctx, req.(*ScheduleCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_SavePreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Preset)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).SavePreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/SavePreset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).SavePreset(ctx, req.(*Preset))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_ListPresets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ListPresets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ListPresets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ListPresets(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_GetPreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PresetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).GetPreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/GetPreset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).GetPreset(ctx, req.(*PresetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leto_DeletePreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PresetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).DeletePreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/DeletePreset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).DeletePreset(ctx, req.(*PresetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Leto_Link_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TrackingLink)
 	if err := dec(in); err != nil {
@@ -248,6 +780,24 @@ func _Leto_Unlink_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Leto_ListBroadcastClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LetoServer).ListBroadcastClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fort.leto.proto.Leto/ListBroadcastClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LetoServer).ListBroadcastClients(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Leto_ServiceDesc is the grpc.ServiceDesc for Leto service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -255,14 +805,34 @@ var Leto_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "fort.leto.proto.Leto",
 	HandlerType: (*LetoServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PrepareTracking",
+			Handler:    _Leto_PrepareTracking_Handler,
+		},
 		{
 			MethodName: "StartTracking",
 			Handler:    _Leto_StartTracking_Handler,
 		},
+		{
+			MethodName: "ValidateConfiguration",
+			Handler:    _Leto_ValidateConfiguration_Handler,
+		},
 		{
 			MethodName: "StopTracking",
 			Handler:    _Leto_StopTracking_Handler,
 		},
+		{
+			MethodName: "PauseTracking",
+			Handler:    _Leto_PauseTracking_Handler,
+		},
+		{
+			MethodName: "ResumeTracking",
+			Handler:    _Leto_ResumeTracking_Handler,
+		},
+		{
+			MethodName: "UpdateConfiguration",
+			Handler:    _Leto_UpdateConfiguration_Handler,
+		},
 		{
 			MethodName: "GetStatus",
 			Handler:    _Leto_GetStatus_Handler,
@@ -271,6 +841,42 @@ var Leto_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLastExperimentLog",
 			Handler:    _Leto_GetLastExperimentLog_Handler,
 		},
+		{
+			MethodName: "ListExperiments",
+			Handler:    _Leto_ListExperiments_Handler,
+		},
+		{
+			MethodName: "GetExperimentLog",
+			Handler:    _Leto_GetExperimentLog_Handler,
+		},
+		{
+			MethodName: "ScheduleTracking",
+			Handler:    _Leto_ScheduleTracking_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Leto_ListSchedules_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _Leto_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "SavePreset",
+			Handler:    _Leto_SavePreset_Handler,
+		},
+		{
+			MethodName: "ListPresets",
+			Handler:    _Leto_ListPresets_Handler,
+		},
+		{
+			MethodName: "GetPreset",
+			Handler:    _Leto_GetPreset_Handler,
+		},
+		{
+			MethodName: "DeletePreset",
+			Handler:    _Leto_DeletePreset_Handler,
+		},
 		{
 			MethodName: "Link",
 			Handler:    _Leto_Link_Handler,
@@ -279,7 +885,17 @@ var Leto_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Unlink",
 			Handler:    _Leto_Unlink_Handler,
 		},
+		{
+			MethodName: "ListBroadcastClients",
+			Handler:    _Leto_ListBroadcastClients_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _Leto_WatchStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "leto_service.proto",
 }